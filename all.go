@@ -107,3 +107,96 @@ func allCompleted(
 
 	return paralleler.RunCompleted()
 }
+
+// AllOf executes the typed functions asynchronously until all functions have been finished. It's
+// the generic version of All, the functions will be called directly without the reflection, and
+// it returns the results of the functions by the functions order. If some function returns an
+// error or panic, it will immediately return an execution error, and send a cancel signal to all
+// other functions by context.
+//
+//	out, err := async.AllOf(func(ctx context.Context) (int, error) {
+//	  return 1, nil
+//	}, func(ctx context.Context) (int, error) {
+//	  time.Sleep(100 * time.Millisecond)
+//	  return 2, nil
+//	})
+//	// out: []int{1, 2}
+//	// err: <nil>
+func AllOf[T any](funcs ...func(context.Context) (T, error)) ([]T, error) {
+	return allOf(context.Background(), funcs...)
+}
+
+// AllOfWithContext executes the typed functions asynchronously until all functions have been
+// finished, or the context is done (canceled or timeout). If some function returns an error or
+// panic, it will immediately return an execution error and send a cancel signal to all other
+// functions by context.
+func AllOfWithContext[T any](
+	ctx context.Context,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	return allOf(ctx, funcs...)
+}
+
+// allOf executes the typed functions asynchronously until all functions have been finished, or the
+// context is done (canceled or timeout).
+func allOf[T any](parent context.Context, funcs ...func(context.Context) (T, error)) ([]T, error) {
+	validateTypedFuncs(funcs...)
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(0)
+
+	return runTasks(paralleler, len(funcs), typedFnTask(funcs))
+}
+
+// AllCompletedOf executes the typed functions asynchronously until all functions have been
+// finished. It's the generic version of AllCompleted, it returns the results of the functions by
+// the functions order, and the execution errors of the functions that returned an error or
+// panicked.
+//
+//	out, err := async.AllCompletedOf(func(ctx context.Context) (int, error) {
+//	  return 1, nil
+//	}, func(ctx context.Context) (int, error) {
+//	  return 0, errors.New("some error")
+//	})
+//	// out: []int{1, 0}
+//	// err: function 1 error: some error
+func AllCompletedOf[T any](funcs ...func(context.Context) (T, error)) ([]T, error) {
+	return allCompletedOf(context.Background(), funcs...)
+}
+
+// AllCompletedOfWithContext executes the typed functions asynchronously until all functions have
+// been finished, or the context is done (canceled or timeout). It returns the results of the
+// functions by the functions order, and the execution errors of the functions that returned an
+// error or panicked.
+func AllCompletedOfWithContext[T any](
+	ctx context.Context,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	return allCompletedOf(ctx, funcs...)
+}
+
+// allCompletedOf executes the typed functions asynchronously until all functions have been
+// finished, or the context is done (canceled or timeout).
+func allCompletedOf[T any](
+	parent context.Context,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	validateTypedFuncs(funcs...)
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(0)
+
+	return runTasksCompleted(paralleler, len(funcs), typedFnTask(funcs))
+}
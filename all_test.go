@@ -268,3 +268,161 @@ func ExampleAllCompleted() {
 	// [[1 <nil>] [expected error]]
 	// function 1 error: expected error
 }
+
+func TestAllOfWithoutFuncs(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.AllOf[int]()
+	a.NilNow(err)
+	a.EqualNow(out, []int{})
+}
+
+func TestAllOfSuccess(t *testing.T) {
+	a := assert.New(t)
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration((5-n)*20) * time.Millisecond)
+			return n, nil
+		})
+	}
+
+	out, err := async.AllOf(funcs...)
+	a.NilNow(err)
+	a.EqualNow(out, []int{0, 1, 2, 3, 4})
+}
+
+func TestAllOfFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("n = 2")
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(n*50) * time.Millisecond)
+			if n == 2 {
+				return n, expectedErr
+			}
+			return n, nil
+		})
+	}
+
+	out, err := async.AllOf(funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: n = 2")
+	a.EqualNow(out, []int{0, 1, 2, 0, 0})
+
+	var execErr async.ExecutionError
+	a.TrueNow(errors.As(err, &execErr))
+	a.EqualNow(execErr.Index(), 2)
+}
+
+func TestAllOfWithPanic(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.AllOf(func(ctx context.Context) (string, error) {
+		return "hello", nil
+	}, func(ctx context.Context) (string, error) {
+		panic("expected panic")
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "function 1 error: expected panic")
+	a.EqualNow(out[1], "")
+}
+
+func TestAllOfWithNilFunc(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.AllOf(func(ctx context.Context) (int, error) {
+			return 1, nil
+		}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestAllOfWithTimeoutContext(t *testing.T) {
+	a := assert.New(t)
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(n*100) * time.Millisecond)
+			return n + 1, nil
+		})
+	}
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer canFunc()
+
+	out, err := async.AllOfWithContext(ctx, funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.EqualNow(out, []int{1, 2, 0, 0, 0})
+}
+
+func ExampleAllOf() {
+	out, err := async.AllOf(func(ctx context.Context) (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	}, func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [1 2]
+	// <nil>
+}
+
+func TestAllCompletedOfPartialFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("n = 2")
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(n*20) * time.Millisecond)
+			if n == 2 {
+				return -1, expectedErr
+			}
+			return n, nil
+		})
+	}
+
+	out, err := async.AllCompletedOf(funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: n = 2")
+	a.EqualNow(out, []int{0, 1, -1, 3, 4})
+}
+
+func TestAllCompletedOfWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	//lint:ignore SA1012 for test case only
+	out, err := async.AllCompletedOfWithContext(nil, func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{1})
+}
+
+func ExampleAllCompletedOf() {
+	out, err := async.AllCompletedOf(func(ctx context.Context) (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	}, func(ctx context.Context) (int, error) {
+		return 0, errors.New("expected error")
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [1 0]
+	// function 1 error: expected error
+}
@@ -0,0 +1,116 @@
+package async
+
+import "context"
+
+// Any executes the functions asynchronously, it will return the index and the result of the first
+// of the function that finished without error or panic, and send a cancel signal to all other
+// functions by context. It returns the execution errors of all the functions if all of the
+// functions returned an error or panicked, and the index will be -1.
+//
+//	out, index, err := async.Any(func(ctx context.Context) (int, error) {
+//	  return 0, errors.New("some error")
+//	}, func(ctx context.Context) (int, error) {
+//	  time.Sleep(50 * time.Millisecond)
+//	  return 1, nil
+//	})
+//	// out: []any{1, <nil>}, index: 1, err: <nil>
+func Any(funcs ...AsyncFn) ([]any, int, error) {
+	return anySuccess(context.Background(), funcs...)
+}
+
+// AnyWithContext executes the functions asynchronously with the specified context, it will return
+// the index and the result of the first of the function that finished without error or panic, and
+// send a cancel signal to all other functions by context. It returns the execution errors of all
+// the functions if all of the functions returned an error or panicked, or a context canceled
+// error if the context was canceled or timed out before any function succeeded.
+func AnyWithContext(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
+	return anySuccess(ctx, funcs...)
+}
+
+// anySuccess executes the functions asynchronously, it will return the index and the result of
+// the first of the succeeded function.
+func anySuccess(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
+	if len(funcs) == 0 {
+		return nil, -1, nil
+	}
+	validateAsyncFuncs(funcs...)
+
+	return anyTasks(ctx, len(funcs), asyncFnTask(funcs))
+}
+
+// AnyOf executes the typed functions asynchronously, it will return the index and the result of
+// the first of the function that finished without error or panic, and send a cancel signal to all
+// other functions by context. It returns the execution errors of all the functions if all of the
+// functions returned an error or panicked, and the index will be -1.
+//
+//	out, index, err := async.AnyOf(func(ctx context.Context) (int, error) {
+//	  return 0, errors.New("some error")
+//	}, func(ctx context.Context) (int, error) {
+//	  time.Sleep(50 * time.Millisecond)
+//	  return 1, nil
+//	})
+//	// out: 1, index: 1, err: <nil>
+func AnyOf[T any](funcs ...func(context.Context) (T, error)) (T, int, error) {
+	return anyOf(context.Background(), funcs...)
+}
+
+// AnyOfWithContext executes the typed functions asynchronously with the specified context, it
+// will return the index and the result of the first of the function that finished without error
+// or panic, and send a cancel signal to all other functions by context.
+func AnyOfWithContext[T any](
+	ctx context.Context,
+	funcs ...func(context.Context) (T, error),
+) (T, int, error) {
+	return anyOf(ctx, funcs...)
+}
+
+// anyOf executes the typed functions asynchronously, it will return the index and the result of
+// the first of the succeeded function.
+func anyOf[T any](ctx context.Context, funcs ...func(context.Context) (T, error)) (T, int, error) {
+	if len(funcs) == 0 {
+		var out T
+		return out, -1, nil
+	}
+	validateTypedFuncs(funcs...)
+
+	return anyTasks(ctx, len(funcs), typedFnTask(funcs))
+}
+
+// anyTasks runs the number of tasks asynchronously, and returns the index and the result of the
+// first of the succeeded task. It'll cancel the other tasks by the context after a task succeeded.
+func anyTasks[T any](parent context.Context, num int, fn taskFn[T]) (T, int, error) {
+	var out T
+	parent = getContext(parent)
+	ctx, canFunc := context.WithCancel(parent)
+	defer canFunc()
+
+	ch := make(chan executeResult[T], num)
+
+	for i := 0; i < num; i++ {
+		go func(n int) {
+			ret, err := fn(ctx, n)
+			ch <- executeResult[T]{
+				Index: n,
+				Error: err,
+				Out:   ret,
+			}
+		}(i)
+	}
+
+	errs := make([]error, num)
+	errNum := 0
+	for finished := 0; finished < num; finished++ {
+		select {
+		case <-parent.Done():
+			return out, -1, ErrContextCanceled
+		case ret := <-ch:
+			if ret.Error == nil {
+				return ret.Out, ret.Index, nil
+			}
+			errs[ret.Index] = ret.Error
+			errNum++
+		}
+	}
+
+	return out, -1, convertErrorListToExecutionErrors(errs, errNum)
+}
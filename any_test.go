@@ -0,0 +1,140 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestAnyWithoutFuncs(t *testing.T) {
+	a := assert.New(t)
+
+	out, index, err := async.Any()
+	a.NilNow(err)
+	a.EqualNow(index, -1)
+	a.NilNow(out)
+}
+
+func TestAny(t *testing.T) {
+	a := assert.New(t)
+
+	data := make([]bool, 5)
+	funcs := make([]async.AsyncFn, 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			if n < 2 {
+				return n, fmt.Errorf("n = %d", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return n, ctx.Err()
+			case <-time.After(time.Duration(n*50) * time.Millisecond):
+				data[n] = true
+				return n, nil
+			}
+		})
+	}
+
+	out, index, err := async.Any(funcs...)
+	a.NilNow(err)
+	a.EqualNow(index, 2)
+	a.EqualNow(out, []any{2, nil})
+
+	time.Sleep(250 * time.Millisecond)
+	a.EqualNow(data, []bool{false, false, true, false, false})
+}
+
+func TestAnyAllFailed(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, index, err := async.Any(func() error {
+		return expectedErr
+	}, func() error {
+		time.Sleep(50 * time.Millisecond)
+		panic(expectedErr)
+	})
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), `function 0 error: expected error
+function 1 error: expected error`)
+	a.EqualNow(index, -1)
+	a.NilNow(out)
+
+	var errs async.ExecutionErrors
+	a.TrueNow(errors.As(err, &errs))
+	a.EqualNow(len(errs), 2)
+}
+
+func TestAnyWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	out, index, err := async.AnyWithContext(ctx, func() error {
+		return errors.New("expected error")
+	}, func() error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	a.EqualNow(err, async.ErrContextCanceled)
+	a.EqualNow(index, -1)
+	a.NilNow(out)
+}
+
+func ExampleAny() {
+	out, index, err := async.Any(func() (int, error) {
+		return 0, errors.New("expected error")
+	}, func() (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	})
+	fmt.Println(out)
+	fmt.Println(index)
+	fmt.Println(err)
+	// Output:
+	// [1 <nil>]
+	// 1
+	// <nil>
+}
+
+func TestAnyOf(t *testing.T) {
+	a := assert.New(t)
+
+	out, index, err := async.AnyOf(func(ctx context.Context) (string, error) {
+		return "", errors.New("expected error")
+	}, func(ctx context.Context) (string, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "hello", nil
+	})
+	a.NilNow(err)
+	a.EqualNow(index, 1)
+	a.EqualNow(out, "hello")
+
+	out, index, err = async.AnyOf[string]()
+	a.NilNow(err)
+	a.EqualNow(index, -1)
+	a.EqualNow(out, "")
+}
+
+func TestAnyOfAllFailed(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	//lint:ignore SA1012 for test case only
+	out, index, err := async.AnyOfWithContext(nil, func(ctx context.Context) (int, error) {
+		return 1, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 0 error: expected error")
+	a.EqualNow(index, -1)
+	a.EqualNow(out, 0)
+}
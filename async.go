@@ -2,6 +2,8 @@ package async
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/ghosind/go-try"
@@ -20,14 +22,15 @@ type AsyncFn any
 
 // executeResult indicates the execution result whether the function returns an error or panic, and
 // the index of the function in the parameters list.
-type executeResult struct {
+type executeResult[T any] struct {
 	// Error is the execution result of the function, it will be nil if the function does not return
 	// an error and does not panic.
 	Error error
 	// Index is the index of the function in the parameters list.
 	Index int
-	// Out is an array to store the return values without the last error.
-	Out []any
+	// Out is the return value of the function. For the AsyncFn, it is an array to store the return
+	// values without the last error.
+	Out T
 }
 
 // empty is a smallest cost struct.
@@ -59,6 +62,15 @@ func validateAsyncFuncs(funcs ...AsyncFn) {
 	}
 }
 
+// validateTypedFuncs validates the typed functions list, and it will panic if any function is nil.
+func validateTypedFuncs[T any](funcs ...func(context.Context) (T, error)) {
+	for _, fn := range funcs {
+		if fn == nil {
+			panic(ErrNotFunction)
+		}
+	}
+}
+
 // isContextType returns a boolean value to indicates whether the type is context or not.
 func isContextType(ty reflect.Type) bool {
 	return ty.Kind() == reflect.Interface &&
@@ -118,32 +130,35 @@ func isFirstParamContext(params []any, numIn int) bool {
 // a return values array and the error. It will store the return values into the out array without
 // the error if it is the last return value.
 func invokeAsyncFn(fn AsyncFn, ctx context.Context, params []any) ([]any, error) {
+	in := makeFuncIn(reflect.TypeOf(fn), ctx, params)
+
+	return callAsyncFn(fn, in)
+}
+
+// callAsyncFn calls the function with the reflected input parameters that were made by makeFuncIn,
+// and returns the return values and the error like invokeAsyncFn.
+func callAsyncFn(fn AsyncFn, in []reflect.Value) ([]any, error) {
 	fv := reflect.ValueOf(fn)
 	ft := fv.Type()
 	var out []reflect.Value
 
-	in := makeFuncIn(ft, ctx, params)
-
-	numRet := ft.NumOut()
-	ret := make([]any, numRet)
-
 	_, err := try.Try(func() {
 		out = fv.Call(in)
 	})
 	if err != nil {
-		for i := 0; i < numRet; i++ {
-			ret[i] = reflect.Zero(ft.Out(i)).Interface()
-		}
-		return ret, err
+		return makeZeroFuncOut(ft), err
 	}
 
+	numRet := ft.NumOut()
+	ret := make([]any, numRet)
+
 	if isFuncReturnsError(ft) {
 		if out[numRet-1].IsNil() {
 			err = nil
 		} else {
 			err = out[numRet-1].Interface().(error)
-			// double check if the error is a custom error pointer
-			if err == nil || reflect.ValueOf(err).IsNil() {
+			// double check if the error is a nil value of a custom error type
+			if err == nil || isNilValue(reflect.ValueOf(err)) {
 				err = nil
 			}
 		}
@@ -155,6 +170,62 @@ func invokeAsyncFn(fn AsyncFn, ctx context.Context, params []any) ([]any, error)
 	return ret, err
 }
 
+// makeZeroFuncOut returns a list of the zero values of the function's return values.
+func makeZeroFuncOut(ft reflect.Type) []any {
+	out := make([]any, ft.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(ft.Out(i)).Interface()
+	}
+
+	return out
+}
+
+// invokeTypedFn calls the typed function with the context directly without the reflection. Like
+// invokeAsyncFn, it catches the panic of the function and returns it as an error, and the return
+// value will be the zero value of the type if the function panics.
+func invokeTypedFn[T any](
+	fn func(context.Context) (T, error),
+	ctx context.Context,
+) (out T, err error) {
+	if try.CatchPanic {
+		defer func() {
+			if e := recover(); e != nil {
+				var zero T
+				out = zero
+				err = convertPanicToError(e)
+			}
+		}()
+	}
+
+	return fn(ctx)
+}
+
+// convertPanicToError converts the recovered value of a panic to an error in the same way as the
+// try package.
+func convertPanicToError(e any) error {
+	switch t := e.(type) {
+	case error:
+		return t
+	case string:
+		return errors.New(t)
+	default:
+		return fmt.Errorf("%v", t)
+	}
+}
+
+// isNilValue returns a boolean value to indicate whether the value is a nil chan, func, map,
+// pointer, slice, or unsafe pointer. It always returns false for the value that can't be nil like
+// a struct.
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.Slice,
+		reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
 // makeFuncIn makes a reflected values list of the parameters to call the function.
 func makeFuncIn(ft reflect.Type, ctx context.Context, params []any) []reflect.Value {
 	isTakeContext, _ := isFuncTakesContexts(ft)
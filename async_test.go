@@ -142,6 +142,66 @@ func TestInvokeAsyncFn(t *testing.T) {
 	}, ctx, nil)
 	a.EqualNow(err, expectErr)
 	a.EqualNow(ret, []any{0})
+
+	ret, err = invokeAsyncFn(func() error { return context.DeadlineExceeded }, ctx, nil)
+	a.EqualNow(err, context.DeadlineExceeded)
+	a.EqualNow(ret, []any{context.DeadlineExceeded})
+
+	ret, err = invokeAsyncFn(func() error { return (*pointerError)(nil) }, ctx, nil)
+	a.NilNow(err)
+	a.EqualNow(ret, []any{(*pointerError)(nil)})
+
+	_, err = invokeAsyncFn(func() error { return mapError(nil) }, ctx, nil)
+	a.NilNow(err)
+
+	_, err = invokeAsyncFn(func() error { return sliceError(nil) }, ctx, nil)
+	a.NilNow(err)
+
+	_, err = invokeAsyncFn(func() error { return funcError(nil) }, ctx, nil)
+	a.NilNow(err)
+
+	_, err = invokeAsyncFn(func() error { return sliceError{"test"} }, ctx, nil)
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "slice error")
+}
+
+// pointerError, mapError, sliceError, and funcError are the custom error types that can be nil.
+type (
+	pointerError struct{}
+	mapError     map[string]string
+	sliceError   []string
+	funcError    func() string
+)
+
+func (*pointerError) Error() string { return "pointer error" }
+func (mapError) Error() string      { return "map error" }
+func (sliceError) Error() string    { return "slice error" }
+func (funcError) Error() string     { return "func error" }
+
+func TestInvokeTypedFn(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	expectErr := errors.New("expected error")
+
+	ret, err := invokeTypedFn(func(ctx context.Context) (int, error) { return 1, nil }, ctx)
+	a.NilNow(err)
+	a.EqualNow(ret, 1)
+
+	ret, err = invokeTypedFn(func(ctx context.Context) (int, error) { return 1, expectErr }, ctx)
+	a.EqualNow(err, expectErr)
+	a.EqualNow(ret, 1)
+
+	ret, err = invokeTypedFn(func(ctx context.Context) (int, error) { panic(expectErr) }, ctx)
+	a.EqualNow(err, expectErr)
+	a.EqualNow(ret, 0)
+
+	ret, err = invokeTypedFn(func(ctx context.Context) (int, error) { panic("panic") }, ctx)
+	a.EqualNow(err.Error(), "panic")
+	a.EqualNow(ret, 0)
+
+	ret, err = invokeTypedFn(func(ctx context.Context) (int, error) { panic(1) }, ctx)
+	a.EqualNow(err.Error(), "1")
+	a.EqualNow(ret, 0)
 }
 
 func TestInvokeVariadicAsyncFn(t *testing.T) {
@@ -0,0 +1,264 @@
+package async
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// AutoTask is a named task of Auto, it contains the function of the task and the names of the
+// tasks that it depends on.
+type AutoTask struct {
+	// Dependencies is the names of the tasks that the task depends on, the task will be run after
+	// all of the dependencies are finished.
+	Dependencies []string
+	// Fn is the function of the task. It receives the return values of the dependencies (without
+	// the last error) as the parameters in the order of Dependencies, and it'll also receive the
+	// context if the function's first parameter is a context.
+	Fn AsyncFn
+}
+
+// autoResult is the execution result of a named task.
+type autoResult struct {
+	// name is the name of the task.
+	name string
+	// out is the return values of the task.
+	out []any
+	// err is the error that the task returned or panicked.
+	err error
+}
+
+// Auto runs the tasks by their dependencies, every task will be run as soon as all of its
+// dependencies are finished, and it receives the return values of its dependencies as the
+// parameters. It returns the return values of all tasks by their names.
+//
+// The tasks will be validated before running, it returns a task error that wraps
+// ErrMissingDependency if a task depends on a task that does not exist, or ErrCyclicDependency if
+// the dependencies have a cycle. If any task returns an error or panics, it will immediately
+// return the results of the finished tasks and a task error that contains the task's name, and
+// send a cancel signal to all other tasks by context.
+//
+//	out, err := async.Auto(map[string]async.AutoTask{
+//	  "user": {
+//	    Fn: func(ctx context.Context) (int, error) {
+//	      return GetUserID(ctx)
+//	    },
+//	  },
+//	  "orders": {
+//	    Dependencies: []string{"user"},
+//	    Fn: func(ctx context.Context, id int) ([]int, error) {
+//	      return GetOrders(ctx, id)
+//	    },
+//	  },
+//	  "profile": {
+//	    Dependencies: []string{"user"},
+//	    Fn: func(ctx context.Context, id int) (string, error) {
+//	      return GetProfile(ctx, id)
+//	    },
+//	  },
+//	})
+//	// out["user"]: []any{1, nil}
+//	// out["orders"]: []any{[]int{...}, nil}
+func Auto(tasks map[string]AutoTask) (map[string][]any, error) {
+	return auto(context.Background(), tasks)
+}
+
+// AutoWithContext runs the tasks by their dependencies with the specified context, every task will
+// be run as soon as all of its dependencies are finished, and it receives the return values of its
+// dependencies as the parameters. It returns the return values of all tasks by their names, or it
+// terminates and returns a task error if any task returns an error or panics.
+func AutoWithContext(
+	ctx context.Context,
+	tasks map[string]AutoTask,
+) (map[string][]any, error) {
+	return auto(ctx, tasks)
+}
+
+// auto validates the tasks and runs them by their dependencies.
+func auto(parent context.Context, tasks map[string]AutoTask) (map[string][]any, error) {
+	if len(tasks) == 0 {
+		return make(map[string][]any), nil
+	}
+
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	// checks and runs the tasks by the order of the names to get a stable result.
+	sort.Strings(names)
+
+	if err := validateAutoTasks(names, tasks); err != nil {
+		return nil, err
+	}
+
+	return runAutoTasks(parent, names, tasks, 0)
+}
+
+// runAutoTasks runs the tasks by their dependencies with the concurrency limitation, the tasks
+// that are ready to run will be started by the order of the names.
+func runAutoTasks(
+	parent context.Context,
+	names []string,
+	tasks map[string]AutoTask,
+	concurrency int,
+) (map[string][]any, error) {
+	out := make(map[string][]any, len(tasks))
+	parent = getContext(parent)
+	ctx, canFunc := context.WithCancel(parent)
+	defer canFunc()
+
+	// pending is the number of the unfinished dependencies of the tasks.
+	pending := make(map[string]int, len(tasks))
+	// dependents is the names of the tasks that depend on the task.
+	dependents := make(map[string][]string, len(tasks))
+	// ready is the names of the tasks that all of their dependencies are finished.
+	ready := make([]string, 0, len(tasks))
+	for _, name := range names {
+		task := tasks[name]
+		pending[name] = len(task.Dependencies)
+		if len(task.Dependencies) == 0 {
+			ready = append(ready, name)
+		}
+		for _, dep := range task.Dependencies {
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	ch := make(chan autoResult, len(tasks))
+	running := 0
+	schedule := func() {
+		for len(ready) > 0 && (concurrency <= 0 || running < concurrency) {
+			name := ready[0]
+			ready = ready[1:]
+			running++
+
+			task := tasks[name]
+			params := make([]any, 0, len(task.Dependencies))
+			for _, dep := range task.Dependencies {
+				params = append(params, getAutoTaskOutputs(tasks[dep].Fn, out[dep])...)
+			}
+
+			go func() {
+				ret, err := invokeAutoTask(task.Fn, ctx, params)
+				ch <- autoResult{
+					name: name,
+					out:  ret,
+					err:  err,
+				}
+			}()
+		}
+	}
+
+	schedule()
+
+	for finished := 0; finished < len(tasks); finished++ {
+		select {
+		case <-parent.Done():
+			return out, ErrContextCanceled
+		case ret := <-ch:
+			if ret.err != nil {
+				return out, &taskError{
+					name: ret.name,
+					err:  ret.err,
+				}
+			}
+
+			running--
+			out[ret.name] = ret.out
+			for _, name := range dependents[ret.name] {
+				pending[name]--
+				if pending[name] == 0 {
+					ready = append(ready, name)
+				}
+			}
+			schedule()
+		}
+	}
+
+	return out, nil
+}
+
+// validateAutoTasks checks the functions and the dependencies of the tasks by the order of the
+// names. It'll panic if any function is nil or not a function, and it returns a task error that
+// wraps ErrMissingDependency if any dependency does not exist, or ErrCyclicDependency if the
+// dependencies have a cycle.
+func validateAutoTasks(names []string, tasks map[string]AutoTask) error {
+	for _, name := range names {
+		validateAsyncFuncs(tasks[name].Fn)
+	}
+
+	for _, name := range names {
+		for _, dep := range tasks[name].Dependencies {
+			if _, ok := tasks[dep]; !ok {
+				return &taskError{
+					name: name,
+					err:  ErrMissingDependency,
+				}
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	states := make(map[string]int, len(tasks))
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch states[name] {
+		case visiting:
+			return false
+		case visited:
+			return true
+		}
+
+		states[name] = visiting
+		for _, dep := range tasks[name].Dependencies {
+			if !visit(dep) {
+				return false
+			}
+		}
+		states[name] = visited
+
+		return true
+	}
+
+	for _, name := range names {
+		if !visit(name) {
+			return &taskError{
+				name: name,
+				err:  ErrCyclicDependency,
+			}
+		}
+	}
+
+	return nil
+}
+
+// invokeAutoTask invokes the task's function with the return values of its dependencies, and it
+// returns ErrUnmatchedParam as the error if the return values do not match the parameters of the
+// function.
+func invokeAutoTask(fn AsyncFn, ctx context.Context, params []any) (out []any, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			if e != ErrUnmatchedParam {
+				panic(e)
+			}
+			out = nil
+			err = ErrUnmatchedParam
+		}
+	}()
+
+	return invokeAsyncFn(fn, ctx, params)
+}
+
+// getAutoTaskOutputs returns the return values of the task without the last error if the task's
+// function returns an error.
+func getAutoTaskOutputs(fn AsyncFn, out []any) []any {
+	if isFuncReturnsError(reflect.TypeOf(fn)) {
+		return out[:len(out)-1]
+	}
+
+	return out
+}
@@ -0,0 +1,250 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestAuto(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.Auto(map[string]async.AutoTask{
+		"a": {
+			Fn: func(ctx context.Context) (int, error) {
+				return 1, nil
+			},
+		},
+		"b": {
+			Fn: func() (string, int) {
+				return "b", 2
+			},
+		},
+		"c": {
+			Dependencies: []string{"a", "b"},
+			Fn: func(ctx context.Context, n int, s string, m int) (string, error) {
+				return fmt.Sprintf("%d%s%d", n, s, m), nil
+			},
+		},
+		"d": {
+			Dependencies: []string{"c", "a"},
+			Fn: func(s string, n int) string {
+				return fmt.Sprintf("%s-%d", s, n)
+			},
+		},
+	})
+	a.NilNow(err)
+	a.EqualNow(len(out), 4)
+	a.EqualNow(out["a"], []any{1, nil})
+	a.EqualNow(out["b"], []any{"b", 2})
+	a.EqualNow(out["c"], []any{"1b2", nil})
+	a.EqualNow(out["d"], []any{"1b2-1"})
+}
+
+func TestAutoWithEmptyTasks(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.Auto(nil)
+	a.NilNow(err)
+	a.EqualNow(len(out), 0)
+}
+
+func TestAutoRunAfterDependencies(t *testing.T) {
+	a := assert.New(t)
+	sleepFn := func(d time.Duration) func() error {
+		return func() error {
+			time.Sleep(d)
+			return nil
+		}
+	}
+	var cFinished, dStarted atomic.Int64
+
+	start := time.Now()
+	_, err := async.Auto(map[string]async.AutoTask{
+		"a": {Fn: sleepFn(10 * time.Millisecond)},
+		"b": {Fn: sleepFn(100 * time.Millisecond)},
+		"c": {
+			Dependencies: []string{"a"},
+			Fn: func() {
+				time.Sleep(10 * time.Millisecond)
+				cFinished.Store(int64(time.Since(start)))
+			},
+		},
+		"d": {
+			Dependencies: []string{"b", "c"},
+			Fn: func() {
+				dStarted.Store(int64(time.Since(start)))
+			},
+		},
+	})
+	a.NilNow(err)
+	// c doesn't wait for b, and d waits for both b and c.
+	a.LtNow(time.Duration(cFinished.Load()), 50*time.Millisecond)
+	a.GteNow(time.Duration(dStarted.Load()), 100*time.Millisecond)
+}
+
+func TestAutoWithInvalidTasks(t *testing.T) {
+	a := assert.New(t)
+	fn := func() {}
+
+	out, err := async.Auto(map[string]async.AutoTask{
+		"a": {Fn: fn},
+		"b": {Dependencies: []string{"a", "c"}, Fn: fn},
+	})
+	a.IsErrorNow(err, async.ErrMissingDependency)
+	a.EqualNow(err.Error(), "task b error: missing dependency")
+	a.NilNow(out)
+
+	_, err = async.Auto(map[string]async.AutoTask{
+		"a": {Dependencies: []string{"c"}, Fn: fn},
+		"b": {Dependencies: []string{"a"}, Fn: fn},
+		"c": {Dependencies: []string{"b"}, Fn: fn},
+		"d": {Fn: fn},
+	})
+	a.IsErrorNow(err, async.ErrCyclicDependency)
+
+	_, err = async.Auto(map[string]async.AutoTask{
+		"a": {Dependencies: []string{"a"}, Fn: fn},
+	})
+	a.IsErrorNow(err, async.ErrCyclicDependency)
+
+	a.PanicOfNow(func() {
+		async.Auto(map[string]async.AutoTask{
+			"a": {Fn: nil},
+		})
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		async.Auto(map[string]async.AutoTask{
+			"a": {Fn: 1},
+		})
+	}, async.ErrNotFunction)
+}
+
+func TestAutoWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	isRun := atomic.Bool{}
+	isCanceled := atomic.Bool{}
+
+	out, err := async.Auto(map[string]async.AutoTask{
+		"a": {
+			Fn: func() int {
+				return 1
+			},
+		},
+		"b": {
+			Dependencies: []string{"a"},
+			Fn: func(ctx context.Context, n int) error {
+				time.Sleep(10 * time.Millisecond)
+				return expectedErr
+			},
+		},
+		"c": {
+			Fn: func(ctx context.Context) error {
+				select {
+				case <-ctx.Done():
+					isCanceled.Store(true)
+				case <-time.After(100 * time.Millisecond):
+				}
+				return nil
+			},
+		},
+		"d": {
+			Dependencies: []string{"b"},
+			Fn: func() {
+				isRun.Store(true)
+			},
+		},
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "task b error: expected error")
+	a.EqualNow(out["a"], []any{1})
+
+	var taskErr async.TaskError
+	a.TrueNow(errors.As(err, &taskErr))
+	a.EqualNow(taskErr.Name(), "b")
+	a.EqualNow(taskErr.Err(), expectedErr)
+
+	time.Sleep(10 * time.Millisecond)
+	a.TrueNow(isCanceled.Load())
+	a.NotTrueNow(isRun.Load())
+}
+
+func TestAutoWithPanic(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := async.Auto(map[string]async.AutoTask{
+		"a": {
+			Fn: func() {
+				panic("expected panic")
+			},
+		},
+	})
+	a.EqualNow(err.Error(), "task a error: expected panic")
+}
+
+func TestAutoWithUnmatchedParams(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := async.Auto(map[string]async.AutoTask{
+		"a": {
+			Fn: func() (int, error) {
+				return 1, nil
+			},
+		},
+		"b": {
+			Dependencies: []string{"a"},
+			Fn:           func(x, y int) {},
+		},
+	})
+	a.IsErrorNow(err, async.ErrUnmatchedParam)
+	a.EqualNow(err.Error(), "task b error: parameters are unmatched")
+}
+
+func TestAutoWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	_, err := async.AutoWithContext(ctx, map[string]async.AutoTask{
+		"a": {
+			Fn: func() {
+				time.Sleep(100 * time.Millisecond)
+			},
+		},
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleAuto() {
+	out, err := async.Auto(map[string]async.AutoTask{
+		"x": {
+			Fn: func(ctx context.Context) (int, error) {
+				return 1, nil
+			},
+		},
+		"y": {
+			Fn: func(ctx context.Context) (int, error) {
+				return 2, nil
+			},
+		},
+		"sum": {
+			Dependencies: []string{"x", "y"},
+			Fn: func(ctx context.Context, x, y int) (int, error) {
+				return x + y, nil
+			},
+		},
+	})
+	fmt.Println(out["sum"][0])
+	fmt.Println(err)
+	// Output:
+	// 3
+	// <nil>
+}
@@ -0,0 +1,116 @@
+package async
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffFunc is the function to calculate the duration to wait before the next retry. It accepts
+// the number of the attempts that have failed (starts from 1), and the duration that waited before
+// the previous attempt (it'll be 0 for the first retry).
+type BackoffFunc func(attempt int, prev time.Duration) time.Duration
+
+// ConstantBackoff returns a backoff function that always waits for the same duration between
+// retries.
+//
+//	async.Retry(fn, async.RetryOptions{
+//	  Backoff: async.ConstantBackoff(100 * time.Millisecond),
+//	}) // 100ms, 100ms, 100ms, ...
+func ConstantBackoff(delay time.Duration) BackoffFunc {
+	return func(int, time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a backoff function that doubles the duration to wait after each
+// retry, it starts from the base duration and never exceeds the max delay. There is no
+// limitation of the max delay if it's less than or equal to 0.
+//
+//	async.Retry(fn, async.RetryOptions{
+//	  Backoff: async.ExponentialBackoff(100*time.Millisecond, time.Second),
+//	}) // 100ms, 200ms, 400ms, 800ms, 1s, 1s, ...
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int, _ time.Duration) time.Duration {
+		return exponentialDelay(base, maxDelay, attempt)
+	}
+}
+
+// FullJitterBackoff returns a backoff function that waits for a random duration between 0 and the
+// exponential backoff duration, the exponential backoff duration starts from the base duration and
+// never exceeds the max delay. There is no limitation of the max delay if it's less than or
+// equal to 0.
+//
+//	async.Retry(fn, async.RetryOptions{
+//	  Backoff: async.FullJitterBackoff(100*time.Millisecond, time.Second),
+//	}) // [0, 100ms), [0, 200ms), [0, 400ms), [0, 800ms), [0, 1s), ...
+func FullJitterBackoff(base, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int, _ time.Duration) time.Duration {
+		return randomDuration(0, exponentialDelay(base, maxDelay, attempt))
+	}
+}
+
+// DecorrelatedJitterBackoff returns a backoff function that waits for a random duration between
+// the base duration and three times the previous duration, and it never exceeds the max delay.
+// There is no limitation of the max delay if it's less than or equal to 0.
+//
+//	async.Retry(fn, async.RetryOptions{
+//	  Backoff: async.DecorrelatedJitterBackoff(100*time.Millisecond, time.Second),
+//	})
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) BackoffFunc {
+	return func(_ int, prev time.Duration) time.Duration {
+		if prev < base {
+			prev = base
+		}
+
+		upper := prev * 3
+		if upper/3 != prev {
+			// overflowed
+			upper = math.MaxInt64
+		}
+
+		return capDelay(randomDuration(base, upper), maxDelay)
+	}
+}
+
+// exponentialDelay returns the duration of the exponential backoff for the attempt, it's the base
+// duration multiplied by 2 to the power of the attempt minus 1, and capped by the max delay.
+func exponentialDelay(base, maxDelay time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+		if maxDelay > 0 && delay >= maxDelay {
+			break
+		}
+	}
+
+	return capDelay(delay, maxDelay)
+}
+
+// capDelay returns the max delay if the duration is greater than it, and the max delay that
+// is less than or equal to 0 means no limitation.
+func capDelay(delay, maxDelay time.Duration) time.Duration {
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// randomDuration returns a random duration in the range [lower, upper).
+func randomDuration(lower, upper time.Duration) time.Duration {
+	if upper <= lower {
+		return lower
+	}
+	return lower + time.Duration(rand.Int63n(int64(upper-lower)))
+}
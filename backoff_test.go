@@ -0,0 +1,73 @@
+package async_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	a := assert.New(t)
+
+	backoff := async.ConstantBackoff(100 * time.Millisecond)
+	for i := 1; i <= 5; i++ {
+		a.EqualNow(backoff(i, 0), 100*time.Millisecond)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	a := assert.New(t)
+
+	backoff := async.ExponentialBackoff(100*time.Millisecond, time.Second)
+	a.EqualNow(backoff(1, 0), 100*time.Millisecond)
+	a.EqualNow(backoff(2, 0), 200*time.Millisecond)
+	a.EqualNow(backoff(3, 0), 400*time.Millisecond)
+	a.EqualNow(backoff(4, 0), 800*time.Millisecond)
+	a.EqualNow(backoff(5, 0), time.Second)
+	a.EqualNow(backoff(100, 0), time.Second)
+
+	backoff = async.ExponentialBackoff(time.Second, 0)
+	a.EqualNow(backoff(3, 0), 4*time.Second)
+	a.EqualNow(backoff(100, 0), time.Duration(math.MaxInt64))
+
+	backoff = async.ExponentialBackoff(0, time.Second)
+	a.EqualNow(backoff(3, 0), time.Duration(0))
+}
+
+func TestFullJitterBackoff(t *testing.T) {
+	a := assert.New(t)
+
+	backoff := async.FullJitterBackoff(100*time.Millisecond, time.Second)
+	for i := 0; i < 100; i++ {
+		delay := backoff(1, 0)
+		a.GteNow(delay, time.Duration(0))
+		a.LtNow(delay, 100*time.Millisecond)
+
+		delay = backoff(10, 0)
+		a.GteNow(delay, time.Duration(0))
+		a.LtNow(delay, time.Second)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	a := assert.New(t)
+
+	backoff := async.DecorrelatedJitterBackoff(100*time.Millisecond, time.Second)
+	prev := time.Duration(0)
+	for i := 1; i <= 100; i++ {
+		delay := backoff(i, prev)
+		a.GteNow(delay, 100*time.Millisecond)
+		a.LteNow(delay, time.Second)
+		if prev > 0 {
+			a.LtNow(delay, prev*3)
+		}
+		prev = delay
+	}
+
+	backoff = async.DecorrelatedJitterBackoff(time.Second, 0)
+	delay := backoff(1, time.Duration(math.MaxInt64))
+	a.GteNow(delay, time.Second)
+}
@@ -0,0 +1,93 @@
+package async
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryBudget is a token bucket to limit the number of retries, it can be shared by multiple Retry
+// callers to limit the total retries to a downstream service. Each retry takes a token from the
+// bucket, and the tokens will be refilled at the specified rate. Retry will give up and return an
+// error that is ErrRetryBudgetExhausted if there is no token in the bucket.
+//
+//	// Allows 10 retries per second with 20 burst retries for all callers.
+//	budget := async.NewRetryBudget(10, 20)
+//
+//	async.Retry(fn, async.RetryOptions{
+//	  Budget: budget,
+//	})
+type RetryBudget struct {
+	locker sync.Mutex
+	bucket tokenBucket
+}
+
+// tokenBucket is a token bucket that refills the tokens at the specified rate, it's not safe for
+// concurrent use.
+type tokenBucket struct {
+	// rate is the number of tokens to refill per second.
+	rate float64
+	// burst is the max number of tokens in the bucket.
+	burst float64
+	// tokens is the number of available tokens.
+	tokens float64
+	// last is the last time that refilled the tokens.
+	last time.Time
+}
+
+// NewRetryBudget creates a retry budget that refills the tokens at the specified rate per second,
+// and holds no more than the burst number of tokens. The bucket is full when it's created.
+func NewRetryBudget(rate float64, burst int) *RetryBudget {
+	if rate < 0 || burst < 0 {
+		panic(ErrInvalidRetryBudget)
+	}
+
+	return &RetryBudget{
+		bucket: newTokenBucket(rate, burst),
+	}
+}
+
+// newTokenBucket creates a full token bucket with the rate per second and the burst.
+func newTokenBucket(rate float64, burst int) tokenBucket {
+	return tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// TryAcquire tries to take a token from the budget for a retry, it returns false if there is no
+// available token.
+func (b *RetryBudget) TryAcquire() bool {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	b.bucket.refill()
+	if b.bucket.tokens < 1 {
+		return false
+	}
+
+	b.bucket.tokens--
+	return true
+}
+
+// Available returns the number of the available tokens in the budget.
+func (b *RetryBudget) Available() int {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	b.bucket.refill()
+	return int(b.bucket.tokens)
+}
+
+// refill adds the tokens that are generated since the last refilling into the bucket.
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	elapsed := now.Sub(b.last)
+	b.last = now
+
+	b.tokens += elapsed.Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,48 @@
+package async_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestRetryBudget(t *testing.T) {
+	a := assert.New(t)
+
+	budget := async.NewRetryBudget(20, 2)
+	a.EqualNow(budget.Available(), 2)
+	a.TrueNow(budget.TryAcquire())
+	a.TrueNow(budget.TryAcquire())
+	a.NotTrueNow(budget.TryAcquire())
+	a.EqualNow(budget.Available(), 0)
+
+	time.Sleep(60 * time.Millisecond)
+	a.EqualNow(budget.Available(), 1)
+	a.TrueNow(budget.TryAcquire())
+	a.NotTrueNow(budget.TryAcquire())
+
+	time.Sleep(200 * time.Millisecond)
+	a.EqualNow(budget.Available(), 2)
+}
+
+func TestRetryBudgetWithoutRefilling(t *testing.T) {
+	a := assert.New(t)
+
+	budget := async.NewRetryBudget(0, 1)
+	a.TrueNow(budget.TryAcquire())
+	time.Sleep(10 * time.Millisecond)
+	a.NotTrueNow(budget.TryAcquire())
+}
+
+func TestNewRetryBudgetWithInvalidParameters(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewRetryBudget(-1, 1)
+	}, async.ErrInvalidRetryBudget)
+	a.PanicOfNow(func() {
+		async.NewRetryBudget(1, -1)
+	}, async.ErrInvalidRetryBudget)
+}
@@ -0,0 +1,394 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultCargoConcurrency int = 1
+
+type CargoOptions struct {
+	// Concurrency is the number of the batches that can be run at the same time, the default is 1.
+	Concurrency int
+	// MaxSize is the maximum number of the items in a batch, there is no limitation if it's 0
+	// (default).
+	MaxSize int
+	// MaxWait is the maximum duration that an item waits for the batch to be filled, the batch will
+	// be run after the first item in it has waited for MaxWait even if the batch is not full. The
+	// batch will be run as soon as a worker is available if it's 0 (default).
+	MaxWait time.Duration
+}
+
+// Cargo is a long-lived batching executor that accumulates the pushed items into batches, and runs
+// the worker function with the batches with the specific concurrency, it's similar to the cargo of
+// Node.js async library. A batch will be run when it reaches the max size, or the first item in it
+// has waited for the max wait duration, and the items that are pushed while all workers are busy
+// will be accumulated into the next batch.
+//
+// The worker function returns the results of the items in the order of the batch, the errors of
+// the items (can be nil if no item failed), and the error of the whole batch. Every pushed item
+// gets a future to get its own result and error, and all items in the batch will be failed with
+// the batch's error if the worker function returns an error or panics.
+//
+//	c := async.NewCargo(func(ctx context.Context, events []Event) ([]int, []error, error) {
+//	  ids, err := BulkWrite(ctx, events)
+//	  return ids, nil, err
+//	}, async.CargoOptions{
+//	  MaxSize: 100,
+//	  MaxWait: 50 * time.Millisecond,
+//	})
+//	id, err := c.Push(event).Await(context.Background())
+//	// Stop receiving the new items and wait for the pushed items to finish.
+//	c.Close(context.Background())
+type Cargo[T, R any] struct {
+	locker sync.Mutex
+	opts   CargoOptions
+	// parent is the context that the cargo was created with, it passes to the functions that are
+	// chained on the items' futures.
+	parent   context.Context
+	ctx      context.Context
+	canFunc  context.CancelFunc
+	workerFn func(context.Context, []T) ([]R, []error, error)
+	// items is the pending items that have not been given to a worker.
+	items []*cargoItem[T, R]
+	// timer is the timer to run the batch after the first pending item has waited for MaxWait.
+	timer *time.Timer
+	// running is the number of the running batches.
+	running int
+	// isClosed indicates the cargo is closed and no more items can be pushed.
+	isClosed bool
+	// closed is the channel that will be closed after the cargo is closed and all items are
+	// finished.
+	closed chan struct{}
+}
+
+// cargoItem is a pending item in the cargo.
+type cargoItem[T, R any] struct {
+	task *queueTask[T, R]
+	// pushedAt is the time that the item was pushed.
+	pushedAt time.Time
+}
+
+// cargoResult is the return values of the worker function except the batch error.
+type cargoResult[R any] struct {
+	out  []R
+	errs []error
+}
+
+// NewCargo creates a cargo to run the worker function with the batches of the pushed items. It'll
+// panic if the worker function is nil.
+func NewCargo[T, R any](
+	fn func(context.Context, []T) ([]R, []error, error),
+	opts ...CargoOptions,
+) *Cargo[T, R] {
+	return newCargo(context.Background(), fn, opts...)
+}
+
+// NewCargoWithContext creates a cargo to run the worker function with the batches of the pushed
+// items, and the context will pass to the worker function. The cargo will be closed when the
+// context is done, and the running batches will receive the cancel signal by the context.
+func NewCargoWithContext[T, R any](
+	ctx context.Context,
+	fn func(context.Context, []T) ([]R, []error, error),
+	opts ...CargoOptions,
+) *Cargo[T, R] {
+	return newCargo(ctx, fn, opts...)
+}
+
+// newCargo creates a cargo with the context and the options.
+func newCargo[T, R any](
+	parent context.Context,
+	fn func(context.Context, []T) ([]R, []error, error),
+	opts ...CargoOptions,
+) *Cargo[T, R] {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	c := &Cargo[T, R]{
+		opts:     getCargoOption(opts...),
+		workerFn: fn,
+		closed:   make(chan struct{}),
+	}
+	c.parent = getContext(parent)
+	c.ctx, c.canFunc = context.WithCancel(c.parent)
+
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			c.abort()
+		case <-c.closed:
+		}
+	}()
+
+	return c
+}
+
+// getCargoOption gets the cargo option by the customize option or the default values.
+func getCargoOption(opts ...CargoOptions) CargoOptions {
+	opt := CargoOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultCargoConcurrency
+	}
+	if opt.MaxSize < 0 {
+		opt.MaxSize = 0
+	}
+
+	return opt
+}
+
+// Push pushes the item into the cargo, and returns a future to get the result of the item. The
+// callbacks will be invoked with the result after the item's batch finished. If the cargo has been
+// closed, the future will be failed with ErrQueueClosed.
+func (c *Cargo[T, R]) Push(item T, callbacks ...func(R, error)) *Future[R] {
+	return c.push([]T{item}, callbacks)[0]
+}
+
+// PushMany pushes the items into the cargo in order, and returns the futures to get the results of
+// the items. The callbacks will be invoked with the result after each item's batch finished. If
+// the cargo has been closed, the futures will be failed with ErrQueueClosed.
+func (c *Cargo[T, R]) PushMany(items []T, callbacks ...func(R, error)) []*Future[R] {
+	return c.push(items, callbacks)
+}
+
+// push pushes the items into the cargo, and starts the batches if they are ready to run.
+func (c *Cargo[T, R]) push(data []T, callbacks []func(R, error)) []*Future[R] {
+	futures := make([]*Future[R], 0, len(data))
+	items := make([]*cargoItem[T, R], 0, len(data))
+	now := time.Now()
+	for _, v := range data {
+		item := &cargoItem[T, R]{
+			task: &queueTask[T, R]{
+				data: v,
+				future: &Future[R]{
+					// uses the parent context to keep the chained functions working after the cargo
+					// is closed.
+					ctx:  c.parent,
+					done: make(chan struct{}),
+				},
+				callbacks: callbacks,
+			},
+			pushedAt: now,
+		}
+		items = append(items, item)
+		futures = append(futures, item.task.future)
+	}
+
+	c.locker.Lock()
+	if c.isClosed {
+		c.locker.Unlock()
+		var out R
+		for _, item := range items {
+			item.task.finish(out, ErrQueueClosed)
+		}
+		return futures
+	}
+	c.items = append(c.items, items...)
+	c.process()
+	c.locker.Unlock()
+
+	return futures
+}
+
+// Len returns the number of the pending items in the cargo.
+func (c *Cargo[T, R]) Len() int {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	return len(c.items)
+}
+
+// Running returns the number of the running batches.
+func (c *Cargo[T, R]) Running() int {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	return c.running
+}
+
+// Idle returns a boolean value to indicate whether the cargo has no pending item and running
+// batch.
+func (c *Cargo[T, R]) Idle() bool {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	return len(c.items) == 0 && c.running == 0
+}
+
+// Close closes the cargo gracefully, the cargo will not receive any new item, and the pending items
+// will be run without waiting for MaxWait. It waits for all of the pushed items to finish. If the
+// context is done (canceled or timeout) before all items finished, it will send a cancel signal to
+// the running batches by context, fail the pending items with ErrQueueClosed, and return a context
+// canceled error.
+func (c *Cargo[T, R]) Close(ctx context.Context) error {
+	ctx = getContext(ctx)
+
+	c.locker.Lock()
+	c.isClosed = true
+	c.process()
+	c.checkClosed()
+	c.locker.Unlock()
+
+	select {
+	case <-c.closed:
+		c.canFunc()
+		return nil
+	case <-ctx.Done():
+		c.canFunc()
+		return ErrContextCanceled
+	}
+}
+
+// abort closes the cargo after the cargo's context is done, and fails the pending items with
+// ErrQueueClosed.
+func (c *Cargo[T, R]) abort() {
+	c.locker.Lock()
+	c.isClosed = true
+	items := c.items
+	c.items = nil
+	c.stopTimer()
+	c.checkClosed()
+	c.locker.Unlock()
+
+	var out R
+	for _, item := range items {
+		item.task.finish(out, ErrQueueClosed)
+	}
+}
+
+// process starts the batches while there are available workers and the pending items are ready to
+// run, and sets the timer for the pending items that are not ready. It must be called with the
+// lock held.
+func (c *Cargo[T, R]) process() {
+	// no more batches will be started after the cargo's context is done, and the pending items will
+	// be failed by abort.
+	for c.ctx.Err() == nil && c.running < c.opts.Concurrency && c.isReady() {
+		size := len(c.items)
+		if c.opts.MaxSize > 0 && size > c.opts.MaxSize {
+			size = c.opts.MaxSize
+		}
+
+		batch := make([]*queueTask[T, R], 0, size)
+		for _, item := range c.items[:size] {
+			batch = append(batch, item.task)
+		}
+		for i := 0; i < size; i++ {
+			c.items[i] = nil
+		}
+		c.items = c.items[size:]
+		c.running++
+
+		go c.run(batch)
+	}
+
+	if len(c.items) == 0 || c.ctx.Err() != nil {
+		c.stopTimer()
+	} else if c.timer == nil && !c.isReady() {
+		c.startTimer(c.opts.MaxWait - time.Since(c.items[0].pushedAt))
+	}
+}
+
+// isReady returns a boolean value to indicate whether the pending items are ready to run as a
+// batch, it must be called with the lock held.
+func (c *Cargo[T, R]) isReady() bool {
+	if len(c.items) == 0 {
+		return false
+	}
+
+	return c.isClosed ||
+		(c.opts.MaxSize > 0 && len(c.items) >= c.opts.MaxSize) ||
+		time.Since(c.items[0].pushedAt) >= c.opts.MaxWait
+}
+
+// startTimer sets the timer to process the pending items after the duration, it must be called
+// with the lock held.
+func (c *Cargo[T, R]) startTimer(d time.Duration) {
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		c.locker.Lock()
+		defer c.locker.Unlock()
+
+		if c.timer == timer {
+			c.timer = nil
+		}
+		c.process()
+	})
+	c.timer = timer
+}
+
+// stopTimer stops the timer if it's set, it must be called with the lock held.
+func (c *Cargo[T, R]) stopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+// run runs the worker function with the batch, resolves the items with the results, and starts the
+// next batches after it finished.
+func (c *Cargo[T, R]) run(batch []*queueTask[T, R]) {
+	data := make([]T, 0, len(batch))
+	for _, task := range batch {
+		data = append(data, task.data)
+	}
+
+	ret, err := invokeTypedFn(func(ctx context.Context) (cargoResult[R], error) {
+		out, errs, err := c.workerFn(ctx, data)
+		return cargoResult[R]{
+			out:  out,
+			errs: errs,
+		}, err
+	}, c.ctx)
+	if err == nil && !isValidCargoResult(ret, len(batch)) {
+		err = ErrUnmatchedBatchResult
+	}
+
+	for i, task := range batch {
+		var out R
+		if err != nil {
+			task.finish(out, err)
+			continue
+		}
+
+		if len(ret.out) > 0 {
+			out = ret.out[i]
+		}
+		var itemErr error
+		if len(ret.errs) > 0 {
+			itemErr = ret.errs[i]
+		}
+		task.finish(out, itemErr)
+	}
+
+	c.locker.Lock()
+	c.running--
+	c.process()
+	c.checkClosed()
+	c.locker.Unlock()
+}
+
+// isValidCargoResult checks the numbers of the results and the errors of the batch, they can be
+// empty or the same as the size of the batch.
+func isValidCargoResult[R any](ret cargoResult[R], size int) bool {
+	return (len(ret.out) == 0 || len(ret.out) == size) &&
+		(len(ret.errs) == 0 || len(ret.errs) == size)
+}
+
+// checkClosed closes the closed channel if the cargo is closed and all items are finished, it must
+// be called with the lock held.
+func (c *Cargo[T, R]) checkClosed() {
+	if !c.isClosed || c.running > 0 || len(c.items) > 0 {
+		return
+	}
+
+	select {
+	case <-c.closed:
+	default:
+		close(c.closed)
+	}
+}
@@ -0,0 +1,320 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestCargo(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	batches := make([][]int, 0, 3)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		locker.Lock()
+		batches = append(batches, items)
+		locker.Unlock()
+
+		out := make([]int, 0, len(items))
+		for _, item := range items {
+			out = append(out, item*2)
+		}
+		return out, nil, nil
+	}, async.CargoOptions{
+		MaxSize: 2,
+		MaxWait: 100 * time.Millisecond,
+	})
+
+	futures := c.PushMany([]int{1, 2, 3, 4, 5})
+	for i, f := range futures[:4] {
+		out, err := f.Await(context.Background())
+		a.NilNow(err)
+		a.EqualNow(out, (i+1)*2)
+	}
+	// the last item waits for the batch to be filled.
+	a.EqualNow(c.Len(), 1)
+
+	start := time.Now()
+	out, err := futures[4].Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 10)
+	a.GteNow(time.Since(start), 50*time.Millisecond)
+
+	a.NilNow(c.Close(context.Background()))
+	a.TrueNow(c.Idle())
+	a.EqualNow(len(batches), 3)
+	a.EqualNow(batches[0], []int{1, 2})
+	a.EqualNow(batches[1], []int{3, 4})
+	a.EqualNow(batches[2], []int{5})
+}
+
+func TestCargoWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewCargo[int, int](nil)
+	}, async.ErrNotFunction)
+}
+
+func TestCargoAccumulateWhileBusy(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	sizes := make([]int, 0, 2)
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		locker.Lock()
+		sizes = append(sizes, len(items))
+		locker.Unlock()
+
+		started <- struct{}{}
+		<-release
+		return items, nil, nil
+	})
+
+	c.Push(1)
+	<-started
+	a.EqualNow(c.Running(), 1)
+	c.PushMany([]int{2, 3})
+	c.Push(4)
+	a.EqualNow(c.Len(), 3)
+
+	close(release)
+	a.NilNow(c.Close(context.Background()))
+	a.EqualNow(sizes, []int{1, 3})
+}
+
+func TestCargoMaxWait(t *testing.T) {
+	a := assert.New(t)
+	sizes := make(chan int, 2)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		sizes <- len(items)
+		return items, nil, nil
+	}, async.CargoOptions{
+		MaxWait: 30 * time.Millisecond,
+	})
+
+	start := time.Now()
+	c.Push(1)
+	time.Sleep(10 * time.Millisecond)
+	f := c.Push(2)
+	_, err := f.Await(context.Background())
+	a.NilNow(err)
+	dur := time.Since(start)
+	a.GteNow(dur, 30*time.Millisecond)
+	a.LtNow(dur, 50*time.Millisecond)
+	a.EqualNow(<-sizes, 2)
+
+	a.NilNow(c.Close(context.Background()))
+}
+
+func TestCargoConcurrency(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return items, nil, nil
+	}, async.CargoOptions{
+		Concurrency: 2,
+		MaxSize:     1,
+	})
+
+	start := time.Now()
+	c.PushMany([]int{1, 2, 3, 4})
+	a.EqualNow(c.Running(), 2)
+	a.NilNow(c.Close(context.Background()))
+	dur := time.Since(start)
+	a.GteNow(dur, 40*time.Millisecond)
+	a.LtNow(dur, 60*time.Millisecond)
+	a.EqualNow(maxRunning.Load(), 2)
+}
+
+func TestCargoWithItemErrors(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	results := make([]string, 0, 3)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]string, []error, error) {
+		out := make([]string, len(items))
+		errs := make([]error, len(items))
+		for i, item := range items {
+			if item%2 == 0 {
+				errs[i] = expectedErr
+			} else {
+				out[i] = fmt.Sprint(item)
+			}
+		}
+		return out, errs, nil
+	}, async.CargoOptions{
+		MaxWait: 10 * time.Millisecond,
+	})
+
+	callback := func(out string, err error) {
+		results = append(results, fmt.Sprintf("%s:%v", out, err))
+	}
+	c.PushMany([]int{1, 2, 3}, callback)
+
+	a.NilNow(c.Close(context.Background()))
+	a.EqualNow(results, []string{"1:<nil>", ":expected error", "3:<nil>"})
+}
+
+func TestCargoWithBatchError(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		if items[0] == 2 {
+			panic("expected panic")
+		}
+		return nil, nil, expectedErr
+	}, async.CargoOptions{
+		MaxSize: 1,
+	})
+
+	futures := c.PushMany([]int{1, 2})
+	_, err := futures[0].Await(context.Background())
+	a.IsErrorNow(err, expectedErr)
+	_, err = futures[1].Await(context.Background())
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "expected panic")
+
+	a.NilNow(c.Close(context.Background()))
+}
+
+func TestCargoWithUnmatchedResults(t *testing.T) {
+	a := assert.New(t)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		return []int{1}, nil, nil
+	}, async.CargoOptions{
+		MaxSize: 2,
+	})
+
+	futures := c.PushMany([]int{1, 2})
+	for _, f := range futures {
+		_, err := f.Await(context.Background())
+		a.IsErrorNow(err, async.ErrUnmatchedBatchResult)
+	}
+
+	a.NilNow(c.Close(context.Background()))
+}
+
+func TestCargoClose(t *testing.T) {
+	a := assert.New(t)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		return items, nil, nil
+	}, async.CargoOptions{
+		MaxWait: time.Second,
+	})
+	f := c.Push(1)
+
+	// close runs the pending items without waiting.
+	start := time.Now()
+	a.NilNow(c.Close(context.Background()))
+	a.LtNow(time.Since(start), 20*time.Millisecond)
+
+	out, err := f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+
+	_, err = c.Push(2).Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+	a.NilNow(c.Close(context.Background()))
+}
+
+func TestCargoWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithCancel(context.Background())
+	c := async.NewCargoWithContext(
+		ctx,
+		func(ctx context.Context, items []int) ([]int, []error, error) {
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		},
+	)
+	f1 := c.Push(1)
+	f2 := c.Push(2)
+
+	canFunc()
+	_, err := f1.Await(context.Background())
+	a.IsErrorNow(err, context.Canceled)
+	_, err = f2.Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+	a.NilNow(c.Close(context.Background()))
+}
+
+func TestCargoChainFutureAfterClose(t *testing.T) {
+	a := assert.New(t)
+
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		return items, nil, nil
+	})
+	f := c.Push(1)
+	_, err := f.Await(context.Background())
+	a.NilNow(err)
+	a.NilNow(c.Close(context.Background()))
+
+	// the chained functions will not receive a canceled context after the cargo is closed.
+	out, err := async.Then(f, func(ctx context.Context, n int) (int, error) {
+		return n + 1, ctx.Err()
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+
+	out, err = f.Finally(func(ctx context.Context) error {
+		return ctx.Err()
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+}
+
+func ExampleCargo() {
+	c := async.NewCargo(func(ctx context.Context, items []int) ([]int, []error, error) {
+		fmt.Println(items)
+		out := make([]int, 0, len(items))
+		for _, item := range items {
+			out = append(out, item*item)
+		}
+		return out, nil, nil
+	}, async.CargoOptions{
+		MaxSize: 2,
+		MaxWait: time.Second,
+	})
+
+	futures := c.PushMany([]int{1, 2, 3})
+	fmt.Println(c.Close(context.Background()))
+	for _, f := range futures {
+		out, err := f.Await(context.Background())
+		fmt.Println(out, err)
+	}
+	// Output:
+	// [1 2]
+	// [3]
+	// <nil>
+	// 1 <nil>
+	// 4 <nil>
+	// 9 <nil>
+}
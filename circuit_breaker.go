@@ -0,0 +1,360 @@
+package async
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ghosind/go-try"
+)
+
+const (
+	defaultCircuitBreakerConsecutiveFailures int           = 5
+	defaultCircuitBreakerWindowSize          int           = 100
+	defaultCircuitBreakerMinRequests         int           = 10
+	defaultCircuitBreakerCoolDown            time.Duration = 30 * time.Second
+	defaultCircuitBreakerHalfOpenMaxCalls    int           = 1
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed indicates the circuit breaker allows all calls.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen indicates the circuit breaker rejects all calls with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen indicates the circuit breaker allows a limited number of trial calls to check
+	// whether the dependency has recovered.
+	CircuitHalfOpen
+)
+
+// String returns the name of the circuit state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerOptions struct {
+	// ConsecutiveFailures is the number of consecutive failures to open the circuit, it's disabled
+	// if it's less than or equal to 0. The default is 5 if both ConsecutiveFailures and FailureRate
+	// are not set.
+	ConsecutiveFailures int
+	// FailureRate is the rate of failures (between 0 and 1) in the window to open the circuit, it's
+	// disabled if it's less than or equal to 0.
+	FailureRate float64
+	// WindowSize is the number of the most recent calls to calculate the failure rate, the default
+	// is 100.
+	WindowSize int
+	// MinRequests is the minimum number of calls in the window before the failure rate is
+	// evaluated, the default is 10.
+	MinRequests int
+	// CoolDown is the duration that the circuit stays open before it becomes half-open, the default
+	// is 30 seconds.
+	CoolDown time.Duration
+	// HalfOpenMaxCalls is the number of trial calls that are allowed in the half-open state, the
+	// circuit will be closed after all of the trial calls succeeded. The default is 1.
+	HalfOpenMaxCalls int
+	// ErrorFilter is a function that is invoked on an error result, the error will be counted as a
+	// failure if it returns true. All errors are counted as failures if it's not set.
+	ErrorFilter func(error) bool
+	// OnStateChange is the function that is invoked after the state of the circuit changed.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker is a tool to stop calling a failing dependency for a while, it'll reject the calls
+// with ErrCircuitOpen after too many failures, and try to call the dependency again after the cool
+// down duration.
+//
+// The function that wrapped by the circuit breaker can be used with other utilities like Retry and
+// Fallback.
+//
+//	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+//	  ConsecutiveFailures: 3,
+//	  CoolDown:            10 * time.Second,
+//	})
+//
+//	out, err := async.Retry(cb.Wrap(func(ctx context.Context) (int, error) {
+//	  // Call the dependency
+//	}))
+type CircuitBreaker struct {
+	locker sync.Mutex
+	opts   CircuitBreakerOptions
+	// state is the current state of the circuit.
+	state CircuitState
+	// generation increases after the state changed, the results of the calls that were allowed in
+	// the previous generation will be ignored.
+	generation uint64
+	// openedAt is the time that the circuit was opened.
+	openedAt time.Time
+	// window is a ring buffer to store the results of the recent calls, true for failures.
+	window []bool
+	// windowIndex is the next position in the window.
+	windowIndex int
+	// windowCount is the number of the calls in the window.
+	windowCount int
+	// failures is the number of the failures in the window.
+	failures int
+	// consecutiveFailures is the number of the consecutive failures.
+	consecutiveFailures int
+	// halfOpenCalls is the number of the trial calls that are allowed in the half-open state.
+	halfOpenCalls int
+	// halfOpenSuccesses is the number of the succeeded trial calls in the half-open state.
+	halfOpenSuccesses int
+}
+
+// NewCircuitBreaker creates a circuit breaker with the options, it's in the closed state.
+func NewCircuitBreaker(opts ...CircuitBreakerOptions) *CircuitBreaker {
+	opt := getCircuitBreakerOption(opts...)
+
+	return &CircuitBreaker{
+		opts:   opt,
+		state:  CircuitClosed,
+		window: make([]bool, opt.WindowSize),
+	}
+}
+
+// getCircuitBreakerOption gets the circuit breaker option by the customize option or the default
+// values.
+func getCircuitBreakerOption(opts ...CircuitBreakerOptions) CircuitBreakerOptions {
+	opt := CircuitBreakerOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.ConsecutiveFailures <= 0 && opt.FailureRate <= 0 {
+		opt.ConsecutiveFailures = defaultCircuitBreakerConsecutiveFailures
+	}
+	if opt.WindowSize <= 0 {
+		opt.WindowSize = defaultCircuitBreakerWindowSize
+	}
+	if opt.MinRequests <= 0 {
+		opt.MinRequests = defaultCircuitBreakerMinRequests
+	}
+	if opt.CoolDown <= 0 {
+		opt.CoolDown = defaultCircuitBreakerCoolDown
+	}
+	if opt.HalfOpenMaxCalls <= 0 {
+		opt.HalfOpenMaxCalls = defaultCircuitBreakerHalfOpenMaxCalls
+	}
+
+	return opt
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.locker.Lock()
+	from := cb.state
+	to := cb.refreshState(time.Now())
+	cb.locker.Unlock()
+
+	cb.notifyStateChange(from, to)
+
+	return to
+}
+
+// Run calls the function if the circuit allows, and returns the result of the function. It returns
+// ErrCircuitOpen without calling the function if the circuit is open.
+func (cb *CircuitBreaker) Run(fn AsyncFn) ([]any, error) {
+	return cb.run(context.Background(), fn)
+}
+
+// RunWithContext calls the function with the specified context if the circuit allows, and returns
+// the result of the function. It returns ErrCircuitOpen without calling the function if the
+// circuit is open.
+func (cb *CircuitBreaker) RunWithContext(ctx context.Context, fn AsyncFn) ([]any, error) {
+	return cb.run(ctx, fn)
+}
+
+// run calls the function through the circuit breaker.
+func (cb *CircuitBreaker) run(parent context.Context, fn AsyncFn) ([]any, error) {
+	validateAsyncFuncs(fn)
+	ctx := getContext(parent)
+
+	generation, err := cb.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := invokeAsyncFn(fn, ctx, nil)
+	cb.done(generation, err)
+
+	return out, err
+}
+
+// Wrap returns a function that has the same signature as the specified function, and the function
+// will be called through the circuit breaker. If the circuit is open, the returned function returns
+// the zero values and ErrCircuitOpen as the last return value if the function returns an error, or
+// panics with ErrCircuitOpen otherwise.
+func (cb *CircuitBreaker) Wrap(fn AsyncFn) AsyncFn {
+	validateAsyncFuncs(fn)
+
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+	isReturnsError := isFuncReturnsError(ft)
+
+	return reflect.MakeFunc(ft, func(in []reflect.Value) []reflect.Value {
+		generation, err := cb.allow()
+		if err != nil {
+			if !isReturnsError {
+				panic(err)
+			}
+
+			out := make([]reflect.Value, ft.NumOut())
+			for i := 0; i < len(out)-1; i++ {
+				out[i] = reflect.Zero(ft.Out(i))
+			}
+			out[len(out)-1] = reflect.ValueOf(&err).Elem()
+			return out
+		}
+
+		var out []reflect.Value
+		_, err = try.Try(func() {
+			if ft.IsVariadic() {
+				out = fv.CallSlice(in)
+			} else {
+				out = fv.Call(in)
+			}
+		})
+		if err != nil {
+			cb.done(generation, err)
+			panic(err)
+		}
+
+		if isReturnsError {
+			last := out[len(out)-1]
+			if !last.IsNil() {
+				err = last.Interface().(error)
+				if isNilValue(reflect.ValueOf(err)) {
+					err = nil
+				}
+			}
+		}
+		cb.done(generation, err)
+
+		return out
+	}).Interface()
+}
+
+// allow checks whether the circuit allows a call or not, and returns the generation of the call.
+func (cb *CircuitBreaker) allow() (uint64, error) {
+	cb.locker.Lock()
+	from := cb.state
+	to := cb.refreshState(time.Now())
+
+	var err error
+	switch to {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.halfOpenCalls >= cb.opts.HalfOpenMaxCalls {
+			err = ErrCircuitOpen
+		} else {
+			cb.halfOpenCalls++
+		}
+	}
+	generation := cb.generation
+	cb.locker.Unlock()
+
+	cb.notifyStateChange(from, to)
+
+	return generation, err
+}
+
+// done records the result of the call that was allowed in the generation.
+func (cb *CircuitBreaker) done(generation uint64, err error) {
+	isFailure := err != nil && (cb.opts.ErrorFilter == nil || cb.opts.ErrorFilter(err))
+
+	cb.locker.Lock()
+	from := cb.state
+	if generation == cb.generation {
+		switch cb.state {
+		case CircuitClosed:
+			cb.recordClosedResult(isFailure)
+		case CircuitHalfOpen:
+			if isFailure {
+				cb.setState(CircuitOpen, time.Now())
+			} else {
+				cb.halfOpenSuccesses++
+				if cb.halfOpenSuccesses >= cb.opts.HalfOpenMaxCalls {
+					cb.setState(CircuitClosed, time.Now())
+				}
+			}
+		}
+	}
+	to := cb.state
+	cb.locker.Unlock()
+
+	cb.notifyStateChange(from, to)
+}
+
+// recordClosedResult records the result of the call in the closed state, and opens the circuit if
+// the failures reach the thresholds.
+func (cb *CircuitBreaker) recordClosedResult(isFailure bool) {
+	if cb.windowCount == len(cb.window) {
+		if cb.window[cb.windowIndex] {
+			cb.failures--
+		}
+	} else {
+		cb.windowCount++
+	}
+	cb.window[cb.windowIndex] = isFailure
+	cb.windowIndex = (cb.windowIndex + 1) % len(cb.window)
+
+	if isFailure {
+		cb.failures++
+		cb.consecutiveFailures++
+	} else {
+		cb.consecutiveFailures = 0
+	}
+
+	if cb.opts.ConsecutiveFailures > 0 && cb.consecutiveFailures >= cb.opts.ConsecutiveFailures {
+		cb.setState(CircuitOpen, time.Now())
+	} else if cb.opts.FailureRate > 0 && cb.windowCount >= cb.opts.MinRequests &&
+		float64(cb.failures)/float64(cb.windowCount) >= cb.opts.FailureRate {
+		cb.setState(CircuitOpen, time.Now())
+	}
+}
+
+// refreshState changes the state from open to half-open if the cool down duration elapsed, and
+// returns the current state.
+func (cb *CircuitBreaker) refreshState(now time.Time) CircuitState {
+	if cb.state == CircuitOpen && now.Sub(cb.openedAt) >= cb.opts.CoolDown {
+		cb.setState(CircuitHalfOpen, now)
+	}
+
+	return cb.state
+}
+
+// setState changes the state of the circuit and resets the counters.
+func (cb *CircuitBreaker) setState(state CircuitState, now time.Time) {
+	cb.state = state
+	cb.generation++
+
+	cb.windowIndex = 0
+	cb.windowCount = 0
+	cb.failures = 0
+	cb.consecutiveFailures = 0
+	cb.halfOpenCalls = 0
+	cb.halfOpenSuccesses = 0
+
+	if state == CircuitOpen {
+		cb.openedAt = now
+	}
+}
+
+// notifyStateChange invokes the state change callback if the state changed.
+func (cb *CircuitBreaker) notifyStateChange(from, to CircuitState) {
+	if from != to && cb.opts.OnStateChange != nil {
+		cb.opts.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,253 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	cnt := 0
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 3,
+		CoolDown:            50 * time.Millisecond,
+	})
+	a.EqualNow(cb.State(), async.CircuitClosed)
+
+	fn := func() error {
+		cnt++
+		return expectedErr
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := cb.Run(fn)
+		a.EqualNow(err, expectedErr)
+	}
+	a.EqualNow(cb.State(), async.CircuitOpen)
+
+	out, err := cb.Run(fn)
+	a.EqualNow(err, async.ErrCircuitOpen)
+	a.NilNow(out)
+	a.EqualNow(cnt, 3)
+}
+
+func TestCircuitBreakerResetConsecutiveFailures(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 2,
+	})
+
+	for i := 0; i < 5; i++ {
+		cb.Run(func() error {
+			return expectedErr
+		})
+		cb.Run(func() error {
+			return nil
+		})
+	}
+	a.EqualNow(cb.State(), async.CircuitClosed)
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		FailureRate: 0.5,
+		WindowSize:  10,
+		MinRequests: 4,
+	})
+
+	results := []error{nil, expectedErr, nil}
+	for _, e := range results {
+		ret := e
+		cb.Run(func() error {
+			return ret
+		})
+	}
+	a.EqualNow(cb.State(), async.CircuitClosed)
+
+	cb.Run(func() error {
+		return expectedErr
+	})
+	a.EqualNow(cb.State(), async.CircuitOpen)
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	changes := make([]string, 0)
+	locker := sync.Mutex{}
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 1,
+		CoolDown:            50 * time.Millisecond,
+		HalfOpenMaxCalls:    2,
+		OnStateChange: func(from, to async.CircuitState) {
+			locker.Lock()
+			defer locker.Unlock()
+			changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+		},
+	})
+
+	cb.Run(func() error {
+		return expectedErr
+	})
+	a.EqualNow(cb.State(), async.CircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	a.EqualNow(cb.State(), async.CircuitHalfOpen)
+
+	// fail in the half-open state
+	_, err := cb.Run(func() error {
+		return expectedErr
+	})
+	a.EqualNow(err, expectedErr)
+	a.EqualNow(cb.State(), async.CircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+
+	// only allows 2 trial calls in the half-open state
+	start := make(chan struct{})
+	finish := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go cb.Run(func() error {
+			start <- struct{}{}
+			<-finish
+			return nil
+		})
+		<-start
+	}
+	_, err = cb.Run(func() error {
+		return nil
+	})
+	a.EqualNow(err, async.ErrCircuitOpen)
+	a.EqualNow(cb.State(), async.CircuitHalfOpen)
+
+	close(finish)
+	time.Sleep(10 * time.Millisecond)
+	a.EqualNow(cb.State(), async.CircuitClosed)
+
+	locker.Lock()
+	defer locker.Unlock()
+	a.EqualNow(changes, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	})
+}
+
+func TestCircuitBreakerWithErrorFilter(t *testing.T) {
+	a := assert.New(t)
+	ignoredErr := errors.New("ignored error")
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 1,
+		ErrorFilter: func(err error) bool {
+			return !errors.Is(err, ignoredErr)
+		},
+	})
+
+	_, err := cb.Run(func() error {
+		return ignoredErr
+	})
+	a.EqualNow(err, ignoredErr)
+	a.EqualNow(cb.State(), async.CircuitClosed)
+
+	_, err = cb.Run(func() error {
+		panic("expected panic")
+	})
+	a.EqualNow(err.Error(), "expected panic")
+	a.EqualNow(cb.State(), async.CircuitOpen)
+}
+
+func TestCircuitBreakerWrap(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	cnt := 0
+
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 2,
+	})
+
+	fn := cb.Wrap(func(ctx context.Context, n int) (int, error) {
+		cnt++
+		return n, expectedErr
+	}).(func(context.Context, int) (int, error))
+
+	out, err := fn(context.Background(), 1)
+	a.EqualNow(out, 1)
+	a.EqualNow(err, expectedErr)
+
+	ret, err := async.Retry(cb.Wrap(func(ctx context.Context) (int, error) {
+		cnt++
+		return 2, expectedErr
+	}), async.RetryOptions{
+		Times: 3,
+	})
+	a.IsErrorNow(err, async.ErrCircuitOpen)
+	a.EqualNow(ret, []any{0, async.ErrCircuitOpen})
+	a.EqualNow(cnt, 2)
+
+	err = async.Fallback(cb.Wrap(func() error {
+		return nil
+	}), func() error {
+		return nil
+	})
+	a.NilNow(err)
+
+	noErrFn := cb.Wrap(func() int {
+		return 1
+	}).(func() int)
+	a.PanicOfNow(func() {
+		noErrFn()
+	}, async.ErrCircuitOpen)
+}
+
+func TestCircuitBreakerWrapVariadicFunc(t *testing.T) {
+	a := assert.New(t)
+
+	cb := async.NewCircuitBreaker()
+	fn := cb.Wrap(func(vals ...int) int {
+		sum := 0
+		for _, v := range vals {
+			sum += v
+		}
+		return sum
+	}).(func(...int) int)
+
+	a.EqualNow(fn(1, 2, 3), 6)
+}
+
+func ExampleCircuitBreaker() {
+	cb := async.NewCircuitBreaker(async.CircuitBreakerOptions{
+		ConsecutiveFailures: 2,
+		CoolDown:            time.Second,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := cb.Run(func() error {
+			return errors.New("expected error")
+		})
+		fmt.Println(err)
+	}
+	fmt.Println(cb.State())
+	// Output:
+	// expected error
+	// expected error
+	// circuit breaker is open
+	// open
+}
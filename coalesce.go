@@ -0,0 +1,162 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// coalescer coalesces the bursty calls into the invocations of the function on the leading edge
+// and the trailing edge of the wait period, it's the common implementation of Throttle and
+// Debounce.
+type coalescer struct {
+	locker sync.Mutex
+	ctx    context.Context
+	fn     AsyncFn
+	wait   time.Duration
+	// leading indicates to invoke the function on the leading edge of the wait period.
+	leading bool
+	// trailing indicates to invoke the function on the trailing edge of the wait period.
+	trailing bool
+	// isDebounce indicates to restart the wait period on every call, and not to start a new wait
+	// period after the trailing invocation.
+	isDebounce bool
+	// timer is the timer of the current wait period, it's nil if there is no wait period.
+	timer *time.Timer
+	// current is the last invocation that was started in the current wait period.
+	current *coalescedCall
+	// pending is the invocation that will be started on the trailing edge of the wait period.
+	pending *coalescedCall
+}
+
+// coalescedCall is an invocation of the function that is shared by the coalesced calls.
+type coalescedCall struct {
+	// done is the channel that will be closed after the function is finished.
+	done chan struct{}
+	// out is the return values of the function.
+	out []any
+	// err is the error that the function returned or panicked.
+	err error
+}
+
+// newCoalescer creates a coalescer with the context, the function, the wait duration, and the
+// edges to invoke the function. It'll panic if the function is nil or not a function.
+func newCoalescer(
+	ctx context.Context,
+	fn AsyncFn,
+	wait time.Duration,
+	leading, trailing, isDebounce bool,
+) *coalescer {
+	validateAsyncFuncs(fn)
+
+	return &coalescer{
+		ctx:        getContext(ctx),
+		fn:         fn,
+		wait:       wait,
+		leading:    leading,
+		trailing:   trailing,
+		isDebounce: isDebounce,
+	}
+}
+
+// call coalesces the call into an invocation of the function, and waits for the result of the
+// invocation. It returns ErrContextCanceled if the context is done before the invocation finished,
+// and the invocation will not be canceled.
+func (c *coalescer) call(ctx context.Context) ([]any, error) {
+	ctx = getContext(ctx)
+
+	c.locker.Lock()
+	var call *coalescedCall
+	if c.timer == nil {
+		// starts a new wait period.
+		if c.leading {
+			call = c.invoke()
+		} else {
+			call = c.getPendingCall()
+		}
+		c.startTimer()
+	} else {
+		if c.isDebounce {
+			c.startTimer()
+		}
+		if c.trailing {
+			call = c.getPendingCall()
+		} else {
+			call = c.current
+		}
+	}
+	c.locker.Unlock()
+
+	select {
+	case <-call.done:
+		return call.out, call.err
+	case <-ctx.Done():
+		return nil, ErrContextCanceled
+	}
+}
+
+// getPendingCall returns the invocation on the trailing edge, and creates it if it does not exist.
+// It must be called with the lock held.
+func (c *coalescer) getPendingCall() *coalescedCall {
+	if c.pending == nil {
+		c.pending = &coalescedCall{
+			done: make(chan struct{}),
+		}
+	}
+
+	return c.pending
+}
+
+// invoke creates and starts a new invocation of the function, it must be called with the lock
+// held.
+func (c *coalescer) invoke() *coalescedCall {
+	call := &coalescedCall{
+		done: make(chan struct{}),
+	}
+	c.start(call)
+
+	return call
+}
+
+// start runs the invocation of the function in the background, it must be called with the lock
+// held.
+func (c *coalescer) start(call *coalescedCall) {
+	c.current = call
+
+	go func() {
+		defer close(call.done)
+		call.out, call.err = invokeAsyncFn(c.fn, c.ctx, nil)
+	}()
+}
+
+// startTimer starts a new timer for the wait period, and stops the previous timer if it exists.
+// It must be called with the lock held.
+func (c *coalescer) startTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(c.wait, func() {
+		c.locker.Lock()
+		defer c.locker.Unlock()
+
+		if c.timer != timer {
+			// the timer has been replaced by a new wait period.
+			return
+		}
+		c.timer = nil
+
+		if c.pending == nil {
+			return
+		}
+		call := c.pending
+		c.pending = nil
+		c.start(call)
+		if !c.isDebounce {
+			// the trailing invocation starts a new wait period for throttle.
+			c.startTimer()
+		}
+	})
+	c.timer = timer
+}
@@ -0,0 +1,83 @@
+package async
+
+import "context"
+
+// Concat runs the function with each element in the slice asynchronously, and concatenates the
+// slices that returned by the functions into one slice in the same order as the elements in the
+// slice. If the function returns an error or panics with any element, it will immediately return
+// an execution error that the index is the element's index in the slice, and send a cancel signal
+// to all other functions by context.
+//
+//	dirs := []string{"a", "b"}
+//	files, err := async.Concat(dirs, func(ctx context.Context, dir string) ([]string, error) {
+//	  return ListFiles(dir)
+//	})
+func Concat[In, Out any](in []In, fn func(context.Context, In) ([]Out, error)) ([]Out, error) {
+	return concat(context.Background(), in, 0, fn)
+}
+
+// ConcatWithContext runs the function with each element in the slice asynchronously with the
+// specified context, and concatenates the slices that returned by the functions into one slice in
+// the same order as the elements in the slice. If the function returns an error or panics with any
+// element, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func ConcatWithContext[In, Out any](
+	ctx context.Context,
+	in []In,
+	fn func(context.Context, In) ([]Out, error),
+) ([]Out, error) {
+	return concat(ctx, in, 0, fn)
+}
+
+// ConcatLimit runs the function with each element in the slice asynchronously with the specified
+// concurrency limit, and concatenates the slices that returned by the functions into one slice in
+// the same order as the elements in the slice. If the function returns an error or panics with any
+// element, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func ConcatLimit[In, Out any](
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) ([]Out, error),
+) ([]Out, error) {
+	return concat(context.Background(), in, concurrency, fn)
+}
+
+// ConcatLimitWithContext runs the function with each element in the slice asynchronously with the
+// specified context and the concurrency limit, and concatenates the slices that returned by the
+// functions into one slice in the same order as the elements in the slice. If the function returns
+// an error or panics with any element, it will immediately return an execution error, and send a
+// cancel signal to all other functions by context.
+func ConcatLimitWithContext[In, Out any](
+	ctx context.Context,
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) ([]Out, error),
+) ([]Out, error) {
+	return concat(ctx, in, concurrency, fn)
+}
+
+// concat runs the function with each element in the slice with the specified concurrency, and
+// flattens the results.
+func concat[In, Out any](
+	parent context.Context,
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) ([]Out, error),
+) ([]Out, error) {
+	rets, err := mapSlice(parent, in, concurrency, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 0
+	for _, ret := range rets {
+		size += len(ret)
+	}
+
+	out := make([]Out, 0, size)
+	for _, ret := range rets {
+		out = append(out, ret...)
+	}
+
+	return out, nil
+}
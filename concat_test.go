@@ -0,0 +1,120 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestConcat(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{3, 0, 1, 2}
+	out, err := async.Concat(in, func(ctx context.Context, n int) ([]int, error) {
+		time.Sleep(time.Duration((3-n)*10) * time.Millisecond)
+		ret := make([]int, n)
+		for i := range ret {
+			ret[i] = n
+		}
+		return ret, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{3, 3, 3, 1, 2, 2})
+
+	out, err = async.Concat([]int{}, func(ctx context.Context, n int) ([]int, error) {
+		return []int{n}, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{})
+}
+
+func TestConcatWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Concat[int, int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestConcatWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, err := async.Concat([]int{1, 2}, func(ctx context.Context, n int) ([]int, error) {
+		if n == 1 {
+			return nil, expectedErr
+		}
+		return []int{n}, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 0 error: expected error")
+	a.NilNow(out)
+}
+
+func TestConcatLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := []string{"a,b", "c", "d,e,f", "g"}
+	out, err := async.ConcatLimit(in, 2, func(ctx context.Context, s string) ([]string, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		return strings.Split(s, ","), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []string{"a", "b", "c", "d", "e", "f", "g"})
+	a.EqualNow(maxRunning.Load(), 2)
+
+	out, err = async.ConcatLimitWithContext(
+		context.Background(),
+		in,
+		1,
+		func(ctx context.Context, s string) ([]string, error) {
+			return []string{s}, nil
+		},
+	)
+	a.NilNow(err)
+	a.EqualNow(out, in)
+}
+
+func TestConcatWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	in := []int{1, 2}
+	_, err := async.ConcatWithContext(ctx, in, func(ctx context.Context, n int) ([]int, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return []int{n}, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleConcat() {
+	in := []string{"a,b", "c", "d,e"}
+	out, err := async.Concat(in, func(ctx context.Context, s string) ([]string, error) {
+		return strings.Split(s, ","), nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [a b c d e]
+	// <nil>
+}
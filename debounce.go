@@ -0,0 +1,65 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+type DebounceOptions struct {
+	// Leading indicates to invoke the function on the leading edge of the wait duration.
+	Leading bool
+	// Trailing indicates to invoke the function on the trailing edge of the wait duration. Only the
+	// trailing edge is enabled if both of Leading and Trailing are false (default).
+	Trailing bool
+}
+
+// Debounce creates a debounced function that delays invoking the function until the wait duration
+// has elapsed since the last call. The calls during the wait duration are coalesced into a single
+// invocation, every caller receives the return values and the error of the invocation that it was
+// coalesced into, and the panic of the function will be caught and returned as an error.
+//
+// The context of the debounced function is used for waiting for the result only, the invocation
+// will not be canceled if a caller's context is done.
+//
+//	save := async.Debounce(func(ctx context.Context) error {
+//	  return SaveDraft(ctx)
+//	}, 500*time.Millisecond)
+//	out, err := save(context.Background())
+func Debounce(
+	fn AsyncFn,
+	wait time.Duration,
+	opts ...DebounceOptions,
+) func(context.Context) ([]any, error) {
+	return debounce(context.Background(), fn, wait, opts...)
+}
+
+// DebounceWithContext creates a debounced function that delays invoking the function until the
+// wait duration has elapsed since the last call, and the context will pass to the function.
+func DebounceWithContext(
+	ctx context.Context,
+	fn AsyncFn,
+	wait time.Duration,
+	opts ...DebounceOptions,
+) func(context.Context) ([]any, error) {
+	return debounce(ctx, fn, wait, opts...)
+}
+
+// debounce creates a debounced function with the options.
+func debounce(
+	ctx context.Context,
+	fn AsyncFn,
+	wait time.Duration,
+	opts ...DebounceOptions,
+) func(context.Context) ([]any, error) {
+	opt := DebounceOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if !opt.Leading && !opt.Trailing {
+		opt.Trailing = true
+	}
+
+	c := newCoalescer(ctx, fn, wait, opt.Leading, opt.Trailing, true)
+
+	return c.call
+}
@@ -0,0 +1,161 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestDebounce(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Debounce(func() int32 {
+		return cnt.Add(1)
+	}, 20*time.Millisecond)
+
+	start := time.Now()
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	ch3 := callCoalescedFn(&wg, fn, 20*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.EqualNow(<-ch2, int32(1))
+	a.EqualNow(<-ch3, int32(1))
+	// every call restarts the wait duration.
+	a.GteNow(time.Since(start), 40*time.Millisecond)
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 1)
+
+	ch4 := callCoalescedFn(&wg, fn, 0)
+	a.EqualNow(<-ch4, int32(2))
+}
+
+func TestDebounceWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Debounce(nil, time.Second)
+	}, async.ErrNotFunction)
+}
+
+func TestDebounceLeadingOnly(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Debounce(func() int32 {
+		return cnt.Add(1)
+	}, 20*time.Millisecond, async.DebounceOptions{
+		Leading: true,
+	})
+
+	start := time.Now()
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.EqualNow(<-ch2, int32(1))
+	a.LtNow(time.Since(start), 20*time.Millisecond)
+	wg.Wait()
+
+	time.Sleep(30 * time.Millisecond)
+	a.EqualNow(cnt.Load(), 1)
+}
+
+func TestDebounceLeadingAndTrailing(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Debounce(func() int32 {
+		return cnt.Add(1)
+	}, 20*time.Millisecond, async.DebounceOptions{
+		Leading:  true,
+		Trailing: true,
+	})
+
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	ch3 := callCoalescedFn(&wg, fn, 15*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.EqualNow(<-ch2, int32(2))
+	a.EqualNow(<-ch3, int32(2))
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 2)
+}
+
+func TestDebounceWithError(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	wg := sync.WaitGroup{}
+
+	fn := async.Debounce(func() error {
+		return expectedErr
+	}, 10*time.Millisecond)
+
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 5*time.Millisecond)
+	a.EqualNow(<-ch1, expectedErr)
+	a.EqualNow(<-ch2, expectedErr)
+	wg.Wait()
+}
+
+func TestDebounceWithCallerContext(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	fn := async.Debounce(func() int32 {
+		return cnt.Add(1)
+	}, 20*time.Millisecond)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	_, err := fn(ctx)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+
+	// the invocation will not be canceled by the caller.
+	time.Sleep(20 * time.Millisecond)
+	a.EqualNow(cnt.Load(), 1)
+}
+
+func TestDebounceWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	//lint:ignore SA1029 for test case only
+	ctx := context.WithValue(context.Background(), "key", "value")
+	fn := async.DebounceWithContext(ctx, func(ctx context.Context) (string, error) {
+		return ctx.Value("key").(string), nil
+	}, 10*time.Millisecond)
+
+	out, err := fn(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, []any{"value", nil})
+}
+
+func ExampleDebounce() {
+	cnt := atomic.Int32{}
+	fn := async.Debounce(func() int32 {
+		return cnt.Add(1)
+	}, 10*time.Millisecond)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println(cnt.Load())
+	// Output:
+	// 1
+}
@@ -0,0 +1,133 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// errDecided is an internal error to stop the other predicate functions after the result was
+// decided by a predicate function.
+var errDecided error = errors.New("result decided")
+
+// Detect runs the predicate function with each element in the slice asynchronously, and returns
+// the first element and its index that the predicate function returned true. The order is not
+// guaranteed, it returns the element whose predicate function finished first with true. It'll
+// send a cancel signal to all other predicate functions by context after the element was found.
+// It returns -1 as the index if no element passes the predicate function.
+//
+// If the predicate function returns an error or panics with any element before the element was
+// found, it will immediately return an execution error that the index is the element's index in
+// the slice.
+//
+//	in := []string{"a", "b", "c"}
+//	out, index, err := async.Detect(in, func(ctx context.Context, key string) (bool, error) {
+//	  return Exists(key)
+//	})
+func Detect[T any](in []T, fn func(context.Context, T) (bool, error)) (T, int, error) {
+	return detect(context.Background(), in, 0, fn)
+}
+
+// DetectWithContext runs the predicate function with each element in the slice asynchronously
+// with the specified context, and returns the first element and its index that the predicate
+// function returned true. It'll send a cancel signal to all other predicate functions by context
+// after the element was found. It returns -1 as the index if no element passes the predicate
+// function.
+func DetectWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) (T, int, error) {
+	return detect(ctx, in, 0, fn)
+}
+
+// DetectLimit runs the predicate function with each element in the slice asynchronously with the
+// specified concurrency limit, and returns the first element and its index that the predicate
+// function returned true. It'll send a cancel signal to all other predicate functions by context
+// after the element was found. It returns -1 as the index if no element passes the predicate
+// function.
+func DetectLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (T, int, error) {
+	return detect(context.Background(), in, concurrency, fn)
+}
+
+// DetectLimitWithContext runs the predicate function with each element in the slice
+// asynchronously with the specified context and the concurrency limit, and returns the first
+// element and its index that the predicate function returned true. It'll send a cancel signal to
+// all other predicate functions by context after the element was found. It returns -1 as the
+// index if no element passes the predicate function.
+func DetectLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (T, int, error) {
+	return detect(ctx, in, concurrency, fn)
+}
+
+// detect runs the predicate function with each element in the slice, and returns the first
+// element that the predicate function returned true.
+func detect[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (T, int, error) {
+	var out T
+
+	index, err := findIndex(parent, in, concurrency, true, fn)
+	if err != nil || index < 0 {
+		return out, -1, err
+	}
+
+	return in[index], index, nil
+}
+
+// findIndex runs the predicate function with each element in the slice with the specified
+// concurrency, and returns the index of the element that the predicate function returned the
+// expected value first. It cancels the other predicate functions by context after the expected
+// value was returned, and returns -1 if no predicate function returned the expected value.
+func findIndex[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	expected bool,
+	fn func(context.Context, T) (bool, error),
+) (int, error) {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	task := elementTask(in, fn)
+	_, err := runTasks(paralleler, len(in), func(ctx context.Context, n int) (empty, error) {
+		ret, err := task(ctx, n)
+		if err != nil {
+			return empty{}, err
+		} else if ret == expected {
+			// stop the other predicate functions by the fail-fast mechanism.
+			return empty{}, errDecided
+		}
+		return empty{}, nil
+	})
+	if err == nil {
+		return -1, nil
+	}
+
+	var execErr *executionError
+	if errors.As(err, &execErr) && execErr.err == errDecided {
+		return execErr.index, nil
+	}
+
+	return -1, err
+}
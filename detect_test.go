@@ -0,0 +1,103 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestDetect(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{5, 4, 3, 2, 1}
+	out, index, err := async.Detect(in, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n*10) * time.Millisecond)
+		return n%2 == 0, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+	a.EqualNow(index, 3)
+
+	out, index, err = async.Detect([]int{1, 3}, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, 0)
+	a.EqualNow(index, -1)
+
+	_, index, err = async.Detect([]int{}, isEven)
+	a.NilNow(err)
+	a.EqualNow(index, -1)
+}
+
+func TestDetectWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Detect[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestDetectWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	_, index, err := async.Detect([]int{1, 2}, func(ctx context.Context, n int) (bool, error) {
+		return false, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(index, -1)
+}
+
+func TestDetectLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+
+	in := []string{"a", "bb", "ccc", "dddd"}
+	out, index, err := async.DetectLimit(in, 1, func(ctx context.Context, s string) (bool, error) {
+		a.EqualNow(running.Add(1), 1)
+		defer running.Add(-1)
+		return len(s) == 2, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "bb")
+	a.EqualNow(index, 1)
+
+	n, index, err := async.DetectLimitWithContext(context.Background(), []int{1, 2}, 2, isEven)
+	a.NilNow(err)
+	a.EqualNow(n, 2)
+	a.EqualNow(index, 1)
+}
+
+func TestDetectWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	in := []int{1, 2}
+	_, index, err := async.DetectWithContext(ctx, in, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return true, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.EqualNow(index, -1)
+}
+
+func ExampleDetect() {
+	in := []string{"a", "bb", "ccc"}
+	out, index, err := async.Detect(in, func(ctx context.Context, s string) (bool, error) {
+		return len(s) == 2, nil
+	})
+	fmt.Println(out)
+	fmt.Println(index)
+	fmt.Println(err)
+	// Output:
+	// bb
+	// 1
+	// <nil>
+}
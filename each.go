@@ -0,0 +1,345 @@
+package async
+
+import (
+	"context"
+	"fmt"
+)
+
+// Each runs the function with each element in the slice asynchronously. If the function returns an
+// error or panics with any element, it will immediately return an execution error that the index
+// is the element's index in the slice, and send a cancel signal to all other functions by context.
+//
+//	err := async.Each([]string{"a", "b", "c"}, func(ctx context.Context, key string) error {
+//	  return Delete(key)
+//	})
+func Each[T any](in []T, fn func(context.Context, T) error) error {
+	return eachSlice(context.Background(), in, 0, fn, false)
+}
+
+// EachWithContext runs the function with each element in the slice asynchronously with the
+// specified context. If the function returns an error or panics with any element, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func EachWithContext[T any](ctx context.Context, in []T, fn func(context.Context, T) error) error {
+	return eachSlice(ctx, in, 0, fn, false)
+}
+
+// EachLimit runs the function with each element in the slice asynchronously with the specified
+// concurrency limit. If the function returns an error or panics with any element, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func EachLimit[T any](in []T, concurrency int, fn func(context.Context, T) error) error {
+	return eachSlice(context.Background(), in, concurrency, fn, false)
+}
+
+// EachLimitWithContext runs the function with each element in the slice asynchronously with the
+// specified context and the concurrency limit. If the function returns an error or panics with any
+// element, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func EachLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) error,
+) error {
+	return eachSlice(ctx, in, concurrency, fn, false)
+}
+
+// EachSeries runs the function with each element in the slice one at a time. If the function
+// returns an error or panics with any element, it will return an execution error and no more
+// functions are run.
+func EachSeries[T any](in []T, fn func(context.Context, T) error) error {
+	return eachSeries(context.Background(), in, fn)
+}
+
+// EachSeriesWithContext runs the function with each element in the slice one at a time with the
+// specified context. If the function returns an error or panics with any element, it will return
+// an execution error and no more functions are run.
+func EachSeriesWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) error,
+) error {
+	return eachSeries(ctx, in, fn)
+}
+
+// EachCompleted runs the function with each element in the slice asynchronously until all
+// functions are finished. It returns the execution errors of the elements that the function
+// returned an error or panicked with.
+//
+//	err := async.EachCompleted([]string{"a", "b", "c"}, func(ctx context.Context, key string) error {
+//	  return Delete(key)
+//	})
+//	// err: function 1 error: key b not found
+func EachCompleted[T any](in []T, fn func(context.Context, T) error) error {
+	return eachSlice(context.Background(), in, 0, fn, true)
+}
+
+// EachCompletedWithContext runs the function with each element in the slice asynchronously with
+// the specified context until all functions are finished. It returns the execution errors of the
+// elements that the function returned an error or panicked with.
+func EachCompletedWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) error,
+) error {
+	return eachSlice(ctx, in, 0, fn, true)
+}
+
+// EachLimitCompleted runs the function with each element in the slice asynchronously with the
+// specified concurrency limit until all functions are finished. It returns the execution errors of
+// the elements that the function returned an error or panicked with.
+func EachLimitCompleted[T any](in []T, concurrency int, fn func(context.Context, T) error) error {
+	return eachSlice(context.Background(), in, concurrency, fn, true)
+}
+
+// EachLimitCompletedWithContext runs the function with each element in the slice asynchronously
+// with the specified context and the concurrency limit until all functions are finished. It
+// returns the execution errors of the elements that the function returned an error or panicked
+// with.
+func EachLimitCompletedWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) error,
+) error {
+	return eachSlice(ctx, in, concurrency, fn, true)
+}
+
+// EachMap runs the function with each key and value in the map asynchronously. If the function
+// returns an error or panics with any entry, it will immediately return an execution error, and
+// send a cancel signal to all other functions by context.
+//
+// The map is iterated in an unspecified order, so the index of the execution error is meaningless
+// for the maps. The error of the execution error is a task error that the name is the entry's key
+// formatted by fmt.Sprint, and it can be got by errors.As with TaskError.
+//
+//	err := async.EachMap(records, func(ctx context.Context, id string, record Record) error {
+//	  return Push(id, record)
+//	})
+func EachMap[K comparable, V any](m map[K]V, fn func(context.Context, K, V) error) error {
+	return eachMap(context.Background(), m, 0, fn, false)
+}
+
+// EachMapWithContext runs the function with each key and value in the map asynchronously with the
+// specified context. If the function returns an error or panics with any entry, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func EachMapWithContext[K comparable, V any](
+	ctx context.Context,
+	m map[K]V,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(ctx, m, 0, fn, false)
+}
+
+// EachMapLimit runs the function with each key and value in the map asynchronously with the
+// specified concurrency limit. If the function returns an error or panics with any entry, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func EachMapLimit[K comparable, V any](
+	m map[K]V,
+	concurrency int,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(context.Background(), m, concurrency, fn, false)
+}
+
+// EachMapLimitWithContext runs the function with each key and value in the map asynchronously with
+// the specified context and the concurrency limit. If the function returns an error or panics with
+// any entry, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func EachMapLimitWithContext[K comparable, V any](
+	ctx context.Context,
+	m map[K]V,
+	concurrency int,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(ctx, m, concurrency, fn, false)
+}
+
+// EachMapCompleted runs the function with each key and value in the map asynchronously until all
+// functions are finished. It returns the execution errors of the entries that the function
+// returned an error or panicked with, and the key of each failed entry can be got from the task
+// error that the execution error wraps.
+//
+//	err := async.EachMapCompleted(records, func(ctx context.Context, id string, r Record) error {
+//	  return Push(id, r)
+//	})
+func EachMapCompleted[K comparable, V any](m map[K]V, fn func(context.Context, K, V) error) error {
+	return eachMap(context.Background(), m, 0, fn, true)
+}
+
+// EachMapCompletedWithContext runs the function with each key and value in the map asynchronously
+// with the specified context until all functions are finished. It returns the execution errors of
+// the entries that the function returned an error or panicked with.
+func EachMapCompletedWithContext[K comparable, V any](
+	ctx context.Context,
+	m map[K]V,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(ctx, m, 0, fn, true)
+}
+
+// EachMapLimitCompleted runs the function with each key and value in the map asynchronously with
+// the specified concurrency limit until all functions are finished. It returns the execution
+// errors of the entries that the function returned an error or panicked with.
+func EachMapLimitCompleted[K comparable, V any](
+	m map[K]V,
+	concurrency int,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(context.Background(), m, concurrency, fn, true)
+}
+
+// EachMapLimitCompletedWithContext runs the function with each key and value in the map
+// asynchronously with the specified context and the concurrency limit until all functions are
+// finished. It returns the execution errors of the entries that the function returned an error or
+// panicked with.
+func EachMapLimitCompletedWithContext[K comparable, V any](
+	ctx context.Context,
+	m map[K]V,
+	concurrency int,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMap(ctx, m, concurrency, fn, true)
+}
+
+// EachMapSeries runs the function with each key and value in the map one at a time. If the
+// function returns an error or panics with any entry, it will return an execution error and no
+// more functions are run.
+func EachMapSeries[K comparable, V any](m map[K]V, fn func(context.Context, K, V) error) error {
+	return eachMapSeries(context.Background(), m, fn)
+}
+
+// EachMapSeriesWithContext runs the function with each key and value in the map one at a time with
+// the specified context. If the function returns an error or panics with any entry, it will return
+// an execution error and no more functions are run.
+func EachMapSeriesWithContext[K comparable, V any](
+	ctx context.Context,
+	m map[K]V,
+	fn func(context.Context, K, V) error,
+) error {
+	return eachMapSeries(ctx, m, fn)
+}
+
+// eachSlice runs the function with each element in the slice with the specified concurrency, it'll
+// run until all functions are finished if isCompleted is true.
+func eachSlice[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) error,
+	isCompleted bool,
+) error {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	task := elementTask(in, errorOnlyFn(fn))
+
+	var err error
+	if isCompleted {
+		_, err = runTasksCompleted(paralleler, len(in), task)
+	} else {
+		_, err = runTasks(paralleler, len(in), task)
+	}
+
+	return err
+}
+
+// eachSeries runs the function with each element in the slice by the order.
+func eachSeries[T any](parent context.Context, in []T, fn func(context.Context, T) error) error {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	_, err := mapSeries(parent, in, errorOnlyFn(fn))
+	return err
+}
+
+// eachMap runs the function with each key and value in the map with the specified concurrency,
+// it'll run until all functions are finished if isCompleted is true.
+func eachMap[K comparable, V any](
+	parent context.Context,
+	m map[K]V,
+	concurrency int,
+	fn func(context.Context, K, V) error,
+	isCompleted bool,
+) error {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	return eachSlice(parent, getMapEntries(m), concurrency, mapEntryFn(fn), isCompleted)
+}
+
+// eachMapSeries runs the function with each key and value in the map one at a time.
+func eachMapSeries[K comparable, V any](
+	parent context.Context,
+	m map[K]V,
+	fn func(context.Context, K, V) error,
+) error {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	return eachSeries(parent, getMapEntries(m), mapEntryFn(fn))
+}
+
+// mapEntry is a key-value pair of a map.
+type mapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// getMapEntries returns the key-value pairs of the map in the iteration order.
+func getMapEntries[K comparable, V any](m map[K]V) []mapEntry[K, V] {
+	entries := make([]mapEntry[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, mapEntry[K, V]{
+			key:   k,
+			value: v,
+		})
+	}
+
+	return entries
+}
+
+// mapEntryFn converts the function that accepts a key and a value to a function that accepts a
+// map entry. The error or the panic of the function will be wrapped by a task error that the name
+// is the entry's key.
+func mapEntryFn[K comparable, V any](
+	fn func(context.Context, K, V) error,
+) func(context.Context, mapEntry[K, V]) error {
+	return func(ctx context.Context, entry mapEntry[K, V]) error {
+		_, err := invokeTypedFn(func(ctx context.Context) (empty, error) {
+			return empty{}, fn(ctx, entry.key, entry.value)
+		}, ctx)
+		if err != nil {
+			return &taskError{
+				name: fmt.Sprint(entry.key),
+				err:  err,
+			}
+		}
+
+		return nil
+	}
+}
+
+// errorOnlyFn converts the function that returns an error only to a function that returns an empty
+// value and the error.
+func errorOnlyFn[T any](fn func(context.Context, T) error) func(context.Context, T) (empty, error) {
+	return func(ctx context.Context, v T) (empty, error) {
+		return empty{}, fn(ctx, v)
+	}
+}
@@ -0,0 +1,357 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestEach(t *testing.T) {
+	a := assert.New(t)
+	sum := atomic.Int32{}
+
+	err := async.Each([]int{1, 2, 3, 4, 5}, func(ctx context.Context, n int) error {
+		sum.Add(int32(n))
+		return nil
+	})
+	a.NilNow(err)
+	a.EqualNow(sum.Load(), 15)
+
+	err = async.Each([]int{}, func(ctx context.Context, n int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Each[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		async.EachSeries[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		async.EachMap[string, int](map[string]int{"a": 1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestEachWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	canceled := atomic.Int32{}
+
+	err := async.Each([]int{1, 2, 3, 4}, func(ctx context.Context, n int) error {
+		if n == 2 {
+			return expectedErr
+		}
+
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+
+	time.Sleep(20 * time.Millisecond)
+	a.EqualNow(canceled.Load(), 3)
+}
+
+func TestEachWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	err := async.EachWithContext(ctx, []int{1, 2}, func(ctx context.Context, n int) error {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleEach() {
+	sum := atomic.Int32{}
+
+	err := async.Each([]int{1, 2, 3}, func(ctx context.Context, n int) error {
+		sum.Add(int32(n))
+		return nil
+	})
+	fmt.Println(sum.Load())
+	fmt.Println(err)
+	// Output:
+	// 6
+	// <nil>
+}
+
+func TestEachLimit(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	start := time.Now()
+	err := async.EachLimit([]int{1, 2, 3, 4}, 2, func(ctx context.Context, n int) error {
+		time.Sleep(50 * time.Millisecond)
+		cnt.Add(1)
+		return nil
+	})
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(cnt.Load(), 4)
+	a.GteNow(dur, 100*time.Millisecond)
+	a.LtNow(dur, 150*time.Millisecond)
+
+	ctx := context.Background()
+	err = async.EachLimitWithContext(ctx, []int{1}, 1, func(ctx context.Context, n int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachSeries(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	calls := make([]string, 0, 2)
+
+	err := async.EachSeries([]string{"a", "b", "c"}, func(ctx context.Context, s string) error {
+		calls = append(calls, s)
+		if s == "b" {
+			return expectedErr
+		}
+		return nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+	a.EqualNow(calls, []string{"a", "b"})
+
+	ctx := context.Background()
+	err = async.EachSeriesWithContext(ctx, []string{"a"}, func(ctx context.Context, s string) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachCompleted(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	err := async.EachCompleted([]int{1, 2, 3, 4}, func(ctx context.Context, n int) error {
+		cnt.Add(1)
+		if n%2 == 0 {
+			return fmt.Errorf("n = %d", n)
+		}
+		return nil
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), `function 1 error: n = 2
+function 3 error: n = 4`)
+	a.EqualNow(cnt.Load(), 4)
+
+	var errs async.ExecutionErrors
+	a.TrueNow(errors.As(err, &errs))
+	a.EqualNow(errs[0].Index(), 1)
+	a.EqualNow(errs[1].Index(), 3)
+
+	ctx := context.Background()
+	err = async.EachCompletedWithContext(ctx, []int{1}, func(ctx context.Context, n int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachLimitCompleted(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	cnt := atomic.Int32{}
+
+	err := async.EachLimitCompleted([]int{1, 2, 3}, 1, func(ctx context.Context, n int) error {
+		cnt.Add(1)
+		if n == 1 {
+			return expectedErr
+		}
+		return nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 0 error: expected error")
+	a.EqualNow(cnt.Load(), 3)
+
+	err = async.EachLimitCompletedWithContext(
+		context.Background(),
+		[]int{1},
+		1,
+		func(ctx context.Context, n int) error {
+			return nil
+		},
+	)
+	a.NilNow(err)
+}
+
+func TestEachMap(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	keys := make([]string, 0, 3)
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	err := async.EachMap(m, func(ctx context.Context, k string, v int) error {
+		locker.Lock()
+		defer locker.Unlock()
+		keys = append(keys, fmt.Sprintf("%s%d", k, v))
+		return nil
+	})
+	a.NilNow(err)
+	sort.Strings(keys)
+	a.EqualNow(keys, []string{"a1", "b2", "c3"})
+}
+
+func TestEachMapWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	ctx := context.Background()
+	err := async.EachMapWithContext(ctx, m, func(ctx context.Context, k string, v int) error {
+		if k == "b" {
+			return expectedErr
+		}
+		return nil
+	})
+	a.IsErrorNow(err, expectedErr)
+
+	err = async.EachMapLimit(m, 1, func(ctx context.Context, k string, v int) error {
+		if v == 3 {
+			return expectedErr
+		}
+		return nil
+	})
+	a.IsErrorNow(err, expectedErr)
+
+	err = async.EachMapLimitWithContext(ctx, m, 2, func(ctx context.Context, k string, v int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachMapSeries(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	cnt := 0
+
+	m := map[int]int{1: 1, 2: 2, 3: 3}
+	err := async.EachMapSeries(m, func(ctx context.Context, k, v int) error {
+		a.EqualNow(running.Add(1), 1)
+		defer running.Add(-1)
+		cnt++
+		return nil
+	})
+	a.NilNow(err)
+	a.EqualNow(cnt, 3)
+
+	ctx := context.Background()
+	err = async.EachMapSeriesWithContext(ctx, m, func(ctx context.Context, k, v int) error {
+		if k == 2 {
+			return errors.New("expected error")
+		}
+		return nil
+	})
+	a.NotNilNow(err)
+	var taskErr async.TaskError
+	a.TrueNow(errors.As(err, &taskErr))
+	a.EqualNow(taskErr.Name(), "2")
+	a.EqualNow(taskErr.Err().Error(), "expected error")
+}
+
+func TestEachMapCompleted(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	cnt := atomic.Int32{}
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	err := async.EachMapCompleted(m, func(ctx context.Context, k string, v int) error {
+		cnt.Add(1)
+		if v%2 == 0 {
+			return expectedErr
+		}
+		return nil
+	})
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(cnt.Load(), 4)
+
+	var errs async.ExecutionErrors
+	a.TrueNow(errors.As(err, &errs))
+	a.EqualNow(len(errs), 2)
+	keys := make([]string, 0, 2)
+	for _, e := range errs {
+		var taskErr async.TaskError
+		a.TrueNow(errors.As(e, &taskErr))
+		keys = append(keys, taskErr.Name())
+	}
+	sort.Strings(keys)
+	a.EqualNow(keys, []string{"b", "d"})
+
+	ctx := context.Background()
+	err = async.EachMapCompletedWithContext(ctx, m, func(ctx context.Context, k string, v int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachMapLimitCompleted(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	cnt := atomic.Int32{}
+
+	m := map[int]int{1: 1, 2: 2, 3: 3}
+	err := async.EachMapLimitCompleted(m, 1, func(ctx context.Context, k, v int) error {
+		a.EqualNow(running.Add(1), 1)
+		defer running.Add(-1)
+		cnt.Add(1)
+		if k == 2 {
+			panic("expected panic")
+		}
+		return nil
+	})
+	a.NotNilNow(err)
+	a.EqualNow(cnt.Load(), 3)
+
+	var taskErr async.TaskError
+	a.TrueNow(errors.As(err, &taskErr))
+	a.EqualNow(taskErr.Name(), "2")
+	a.EqualNow(taskErr.Err().Error(), "expected panic")
+
+	ctx := context.Background()
+	err = async.EachMapLimitCompletedWithContext(ctx, m, 2, func(ctx context.Context, k, v int) error {
+		return nil
+	})
+	a.NilNow(err)
+}
+
+func TestEachMapWithFailedKey(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	err := async.EachMap(m, func(ctx context.Context, k string, v int) error {
+		if k == "b" {
+			return expectedErr
+		}
+		return nil
+	})
+	a.IsErrorNow(err, expectedErr)
+
+	var taskErr async.TaskError
+	a.TrueNow(errors.As(err, &taskErr))
+	a.EqualNow(taskErr.Name(), "b")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 var (
@@ -22,6 +23,37 @@ var (
 	ErrInvalidTestFunc error = errors.New("invalid test function")
 	// ErrInvalidSeqFuncs indicates the functions in the Seq lists are not match.
 	ErrInvalidSeqFuncs error = errors.New("invalid seq functions")
+	// ErrUnacceptableResult indicates the function's result is not acceptable by the RetryIf
+	// function of the retry options.
+	ErrUnacceptableResult error = errors.New("unacceptable result")
+	// ErrMaxElapsedExceeded indicates Retry gave up because the max elapsed time was exceeded, or
+	// the next attempt will exceed it.
+	ErrMaxElapsedExceeded error = errors.New("max elapsed time exceeded")
+	// ErrRetryBudgetExhausted indicates Retry gave up because there is no available token in the
+	// retry budget.
+	ErrRetryBudgetExhausted error = errors.New("retry budget exhausted")
+	// ErrAttemptTimeout indicates the attempt of Retry was timed out.
+	ErrAttemptTimeout error = errors.New("attempt timed out")
+	// ErrCircuitOpen indicates the call was rejected because the circuit breaker is open.
+	ErrCircuitOpen error = errors.New("circuit breaker is open")
+	// ErrInvalidRetryBudget indicates the rate or the burst of the retry budget is invalid.
+	ErrInvalidRetryBudget error = errors.New("invalid retry budget")
+	// ErrMissingDependency indicates a task depends on a task that does not exist.
+	ErrMissingDependency error = errors.New("missing dependency")
+	// ErrCyclicDependency indicates the dependencies of the tasks have a cycle.
+	ErrCyclicDependency error = errors.New("cyclic dependency")
+	// ErrDuplicateNode indicates the node name has been added to the graph.
+	ErrDuplicateNode error = errors.New("duplicate node")
+	// ErrQueueClosed indicates the queue has been closed and the task will not be run.
+	ErrQueueClosed error = errors.New("queue is closed")
+	// ErrUnmatchedBatchResult indicates the number of the results or the errors that the batch
+	// function returned does not match the number of the items in the batch.
+	ErrUnmatchedBatchResult error = errors.New("batch results are unmatched")
+	// ErrInvalidSemaphoreWeight indicates the weight to acquire or release is less than 0 or greater
+	// than the size of the semaphore, or the weight to release is greater than the acquired weight.
+	ErrInvalidSemaphoreWeight error = errors.New("invalid semaphore weight")
+	// ErrInvalidRateLimit indicates the rate or the burst of the rate limiter is invalid.
+	ErrInvalidRateLimit error = errors.New("invalid rate limit")
 )
 
 type ExecutionError interface {
@@ -117,3 +149,92 @@ func convertErrorListToExecutionErrors(errs []error, num int) ExecutionErrors {
 
 	return ee
 }
+
+type TaskError interface {
+	// Name returns the name of the task that had returned an error or panicked.
+	Name() string
+	// Err returns the original error that was returned or panicked by the task.
+	Err() error
+	// Error returns the task error message.
+	Error() string
+}
+
+// taskError is the error to represents the error of the named task that is returned or panicked.
+type taskError struct {
+	// name is the name of the task.
+	name string
+	// err is the error that the task returned or panicked.
+	err error
+}
+
+// Name returns the name of the task that had returned an error or panicked.
+func (e *taskError) Name() string {
+	return e.name
+}
+
+// Err returns the original error that was returned or panicked by the task.
+func (e *taskError) Err() error {
+	return e.err
+}
+
+// Error returns the task error message.
+func (e *taskError) Error() string {
+	return fmt.Sprintf("task %s error: %s", e.name, e.err.Error())
+}
+
+// Unwrap returns the inner error.
+func (e *taskError) Unwrap() error {
+	return e.err
+}
+
+// RetryAttempt is the record of a failed attempt of Retry.
+type RetryAttempt struct {
+	// Attempt is the number of the attempt, it starts from 1.
+	Attempt int
+	// Err is the error that the attempt returned or panicked.
+	Err error
+	// StartTime is the time that the attempt started.
+	StartTime time.Time
+	// Duration is the time that the attempt took.
+	Duration time.Duration
+	// Delay is the duration that waited after the attempt before the next attempt, it'll be 0 if
+	// it's the last attempt.
+	Delay time.Duration
+}
+
+// RetryError is the error that Retry returned after it gives up, it records the error and the
+// timing of every failed attempt.
+type RetryError struct {
+	// Attempts is the records of the failed attempts.
+	Attempts []RetryAttempt
+	// Err is the error that made Retry give up, it's the error of the last attempt, or the context's
+	// error if the context was done while waiting for the next attempt.
+	Err error
+	// Reason is the reason why Retry gave up before making all attempts, like
+	// ErrMaxElapsedExceeded and ErrRetryBudgetExhausted. It's nil for other cases.
+	Reason error
+}
+
+// Error returns the retry error message.
+func (e *RetryError) Error() string {
+	if e.Reason != nil {
+		return fmt.Sprintf(
+			"failed after %d attempts (%s): %s",
+			len(e.Attempts),
+			e.Reason.Error(),
+			e.Err.Error(),
+		)
+	}
+
+	return fmt.Sprintf("failed after %d attempts: %s", len(e.Attempts), e.Err.Error())
+}
+
+// Is returns true if the target is the reason why Retry gave up.
+func (e *RetryError) Is(target error) bool {
+	return e.Reason != nil && e.Reason == target
+}
+
+// Unwrap returns the error that made Retry give up.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
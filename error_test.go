@@ -79,3 +79,46 @@ func TestUnwrapExecutionError(t *testing.T) {
 	a.IsErrorNow(err, innerErr)
 	a.NotIsErrorNow(err, errors.New("unexpected error"))
 }
+
+func TestTaskError(t *testing.T) {
+	a := assert.New(t)
+
+	innerErr := errors.New("inner error")
+	err := &taskError{
+		name: "task",
+		err:  innerErr,
+	}
+
+	a.IsErrorNow(err, innerErr)
+	a.EqualNow(err.Name(), "task")
+	a.EqualNow(err.Err(), innerErr)
+	a.EqualNow(err.Error(), "task task error: inner error")
+}
+
+func TestRetryError(t *testing.T) {
+	a := assert.New(t)
+
+	innerErr := errors.New("expected error")
+	err := &RetryError{
+		Attempts: []RetryAttempt{{Attempt: 1, Err: innerErr}, {Attempt: 2, Err: innerErr}},
+		Err:      innerErr,
+	}
+	a.EqualNow(err.Error(), "failed after 2 attempts: expected error")
+	a.IsErrorNow(err, innerErr)
+	a.EqualNow(errors.Unwrap(err), innerErr)
+}
+
+func TestRetryErrorWithReason(t *testing.T) {
+	a := assert.New(t)
+
+	innerErr := errors.New("expected error")
+	err := &RetryError{
+		Attempts: []RetryAttempt{{Attempt: 1, Err: innerErr}},
+		Err:      innerErr,
+		Reason:   ErrMaxElapsedExceeded,
+	}
+	a.EqualNow(err.Error(), "failed after 1 attempts (max elapsed time exceeded): expected error")
+	a.IsErrorNow(err, innerErr)
+	a.IsErrorNow(err, ErrMaxElapsedExceeded)
+	a.NotIsErrorNow(err, ErrRetryBudgetExhausted)
+}
@@ -0,0 +1,72 @@
+package async
+
+import "context"
+
+// Every runs the predicate function with each element in the slice asynchronously, and returns
+// true if all elements pass the predicate function. It'll return false immediately after a
+// predicate function returned false, and send a cancel signal to all other predicate functions by
+// context. It returns true for an empty slice.
+//
+// If the predicate function returns an error or panics with any element before the result was
+// decided, it will immediately return an execution error that the index is the element's index in
+// the slice.
+//
+//	ok, err := async.Every([]string{"a", "b"}, func(ctx context.Context, key string) (bool, error) {
+//	  return Exists(key)
+//	})
+func Every[T any](in []T, fn func(context.Context, T) (bool, error)) (bool, error) {
+	return every(context.Background(), in, 0, fn)
+}
+
+// EveryWithContext runs the predicate function with each element in the slice asynchronously with
+// the specified context, and returns true if all elements pass the predicate function. It'll
+// return false immediately after a predicate function returned false, and send a cancel signal to
+// all other predicate functions by context.
+func EveryWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return every(ctx, in, 0, fn)
+}
+
+// EveryLimit runs the predicate function with each element in the slice asynchronously with the
+// specified concurrency limit, and returns true if all elements pass the predicate function.
+// It'll return false immediately after a predicate function returned false, and send a cancel
+// signal to all other predicate functions by context.
+func EveryLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return every(context.Background(), in, concurrency, fn)
+}
+
+// EveryLimitWithContext runs the predicate function with each element in the slice
+// asynchronously with the specified context and the concurrency limit, and returns true if all
+// elements pass the predicate function. It'll return false immediately after a predicate function
+// returned false, and send a cancel signal to all other predicate functions by context.
+func EveryLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return every(ctx, in, concurrency, fn)
+}
+
+// every runs the predicate function with each element in the slice, and returns true if all
+// elements pass the predicate function.
+func every[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	index, err := findIndex(parent, in, concurrency, false, fn)
+	if err != nil {
+		return false, err
+	}
+
+	return index < 0, nil
+}
@@ -0,0 +1,114 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestEvery(t *testing.T) {
+	a := assert.New(t)
+	canceled := atomic.Int32{}
+
+	start := time.Now()
+	ok, err := async.Every([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (bool, error) {
+		if n == 3 {
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return true, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return true, nil
+		}
+	})
+	a.NilNow(err)
+	a.NotTrueNow(ok)
+	a.LtNow(time.Since(start), 50*time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+	a.EqualNow(canceled.Load(), 3)
+
+	ok, err = async.Every([]int{2, 4, 6}, isEven)
+	a.NilNow(err)
+	a.TrueNow(ok)
+
+	ok, err = async.Every([]int{}, isEven)
+	a.NilNow(err)
+	a.TrueNow(ok)
+}
+
+func TestEveryWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Every[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestEveryWithFailure(t *testing.T) {
+	a := assert.New(t)
+
+	ok, err := async.Every([]int{1, 2, 3}, func(ctx context.Context, n int) (bool, error) {
+		if n == 3 {
+			panic("expected panic")
+		}
+		return true, nil
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "function 2 error: expected panic")
+	a.NotTrueNow(ok)
+}
+
+func TestEveryLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+
+	in := []int{2, 4, 5, 6, 8}
+	ok, err := async.EveryLimit(in, 1, func(ctx context.Context, n int) (bool, error) {
+		a.EqualNow(running.Add(1), 1)
+		defer running.Add(-1)
+		return n%2 == 0, nil
+	})
+	a.NilNow(err)
+	a.NotTrueNow(ok)
+
+	ok, err = async.EveryLimitWithContext(context.Background(), []int{2, 4}, 2, isEven)
+	a.NilNow(err)
+	a.TrueNow(ok)
+}
+
+func TestEveryWithContext(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	ctx := context.Background()
+	ok, err := async.EveryWithContext(ctx, []int{1, 2}, isEven)
+	a.NilNow(err)
+	a.NotTrueNow(ok)
+
+	_, err = async.EveryWithContext(ctx, []int{1}, func(ctx context.Context, n int) (bool, error) {
+		return true, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+}
+
+func ExampleEvery() {
+	ok, err := async.Every([]int{2, 4, 6}, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n%2 == 0, nil
+	})
+	fmt.Println(ok)
+	fmt.Println(err)
+	// Output:
+	// true
+	// <nil>
+}
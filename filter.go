@@ -0,0 +1,283 @@
+package async
+
+import "context"
+
+// Filter runs the predicate function with each element in the slice asynchronously, and returns
+// the elements that the predicate function returned true in the same order as they are in the
+// slice. If the predicate function returns an error or panics with any element, it will
+// immediately return an execution error that the index is the element's index in the slice, and
+// send a cancel signal to all other functions by context.
+//
+//	keys := []string{"a", "b", "c"}
+//	out, err := async.Filter(keys, func(ctx context.Context, key string) (bool, error) {
+//	  return Exists(key)
+//	})
+//	// out: []string{"a", "c"}
+//	// err: <nil>
+func Filter[T any](in []T, fn func(context.Context, T) (bool, error)) ([]T, error) {
+	out, _, err := partition(context.Background(), in, 0, false, fn)
+	return out, err
+}
+
+// FilterWithContext runs the predicate function with each element in the slice asynchronously
+// with the specified context, and returns the elements that the predicate function returned true
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will immediately return an execution error, and send a cancel signal to all
+// other functions by context.
+func FilterWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	out, _, err := partition(ctx, in, 0, false, fn)
+	return out, err
+}
+
+// FilterLimit runs the predicate function with each element in the slice asynchronously with the
+// specified concurrency limit, and returns the elements that the predicate function returned true
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will immediately return an execution error, and send a cancel signal to all
+// other functions by context.
+func FilterLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	out, _, err := partition(context.Background(), in, concurrency, false, fn)
+	return out, err
+}
+
+// FilterLimitWithContext runs the predicate function with each element in the slice
+// asynchronously with the specified context and the concurrency limit, and returns the elements
+// that the predicate function returned true in the same order as they are in the slice. If the
+// predicate function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func FilterLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	out, _, err := partition(ctx, in, concurrency, false, fn)
+	return out, err
+}
+
+// FilterSeries runs the predicate function with each element in the slice one at a time, and
+// returns the elements that the predicate function returned true in the same order as they are in
+// the slice. If the predicate function returns an error or panics with any element, it will
+// return an execution error and no more functions are run.
+func FilterSeries[T any](in []T, fn func(context.Context, T) (bool, error)) ([]T, error) {
+	out, _, err := partition(context.Background(), in, 1, true, fn)
+	return out, err
+}
+
+// FilterSeriesWithContext runs the predicate function with each element in the slice one at a time
+// with the specified context, and returns the elements that the predicate function returned true
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will return an execution error and no more functions are run.
+func FilterSeriesWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	out, _, err := partition(ctx, in, 1, true, fn)
+	return out, err
+}
+
+// Reject runs the predicate function with each element in the slice asynchronously, and returns
+// the elements that the predicate function returned false in the same order as they are in the
+// slice. It's the opposite of Filter. If the predicate function returns an error or panics with
+// any element, it will immediately return an execution error that the index is the element's
+// index in the slice, and send a cancel signal to all other functions by context.
+//
+//	out, err := async.Reject([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (bool, error) {
+//	  return n%2 == 0, nil
+//	})
+//	// out: []int{1, 3}
+//	// err: <nil>
+func Reject[T any](in []T, fn func(context.Context, T) (bool, error)) ([]T, error) {
+	_, out, err := partition(context.Background(), in, 0, false, fn)
+	return out, err
+}
+
+// RejectWithContext runs the predicate function with each element in the slice asynchronously
+// with the specified context, and returns the elements that the predicate function returned false
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will immediately return an execution error, and send a cancel signal to all
+// other functions by context.
+func RejectWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	_, out, err := partition(ctx, in, 0, false, fn)
+	return out, err
+}
+
+// RejectLimit runs the predicate function with each element in the slice asynchronously with the
+// specified concurrency limit, and returns the elements that the predicate function returned false
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will immediately return an execution error, and send a cancel signal to all
+// other functions by context.
+func RejectLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	_, out, err := partition(context.Background(), in, concurrency, false, fn)
+	return out, err
+}
+
+// RejectLimitWithContext runs the predicate function with each element in the slice
+// asynchronously with the specified context and the concurrency limit, and returns the elements
+// that the predicate function returned false in the same order as they are in the slice. If the
+// predicate function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func RejectLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	_, out, err := partition(ctx, in, concurrency, false, fn)
+	return out, err
+}
+
+// RejectSeries runs the predicate function with each element in the slice one at a time, and
+// returns the elements that the predicate function returned false in the same order as they are
+// in the slice. If the predicate function returns an error or panics with any element, it will
+// return an execution error and no more functions are run.
+func RejectSeries[T any](in []T, fn func(context.Context, T) (bool, error)) ([]T, error) {
+	_, out, err := partition(context.Background(), in, 1, true, fn)
+	return out, err
+}
+
+// RejectSeriesWithContext runs the predicate function with each element in the slice one at a time
+// with the specified context, and returns the elements that the predicate function returned false
+// in the same order as they are in the slice. If the predicate function returns an error or panics
+// with any element, it will return an execution error and no more functions are run.
+func RejectSeriesWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, error) {
+	_, out, err := partition(ctx, in, 1, true, fn)
+	return out, err
+}
+
+// Partition runs the predicate function with each element in the slice asynchronously, and splits
+// the elements into two slices: the elements that the predicate function returned true, and the
+// elements that the predicate function returned false. Both slices keep the same order as the
+// elements in the slice. If the predicate function returns an error or panics with any element,
+// it will immediately return an execution error that the index is the element's index in the
+// slice, and send a cancel signal to all other functions by context.
+//
+//	in := []int{1, 2, 3, 4}
+//	pass, fail, err := async.Partition(in, func(ctx context.Context, n int) (bool, error) {
+//	  return n%2 == 0, nil
+//	})
+//	// pass: []int{2, 4}
+//	// fail: []int{1, 3}
+//	// err: <nil>
+func Partition[T any](in []T, fn func(context.Context, T) (bool, error)) ([]T, []T, error) {
+	return partition(context.Background(), in, 0, false, fn)
+}
+
+// PartitionWithContext runs the predicate function with each element in the slice asynchronously
+// with the specified context, and splits the elements into the elements that the predicate
+// function returned true and the elements that the predicate function returned false. If the
+// predicate function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func PartitionWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	return partition(ctx, in, 0, false, fn)
+}
+
+// PartitionLimit runs the predicate function with each element in the slice asynchronously with
+// the specified concurrency limit, and splits the elements into the elements that the predicate
+// function returned true and the elements that the predicate function returned false. If the
+// predicate function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func PartitionLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	return partition(context.Background(), in, concurrency, false, fn)
+}
+
+// PartitionLimitWithContext runs the predicate function with each element in the slice
+// asynchronously with the specified context and the concurrency limit, and splits the elements
+// into the elements that the predicate function returned true and the elements that the predicate
+// function returned false. If the predicate function returns an error or panics with any element,
+// it will immediately return an execution error, and send a cancel signal to all other functions
+// by context.
+func PartitionLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	return partition(ctx, in, concurrency, false, fn)
+}
+
+// PartitionSeries runs the predicate function with each element in the slice one at a time, and
+// splits the elements into the elements that the predicate function returned true and the
+// elements that the predicate function returned false. If the predicate function returns an error
+// or panics with any element, it will return an execution error and no more functions are run.
+func PartitionSeries[T any](
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	return partition(context.Background(), in, 1, true, fn)
+}
+
+// PartitionSeriesWithContext runs the predicate function with each element in the slice one at a
+// time with the specified context, and splits the elements into the elements that the predicate
+// function returned true and the elements that the predicate function returned false. If the
+// predicate function returns an error or panics with any element, it will return an execution
+// error and no more functions are run.
+func PartitionSeriesWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	return partition(ctx, in, 1, true, fn)
+}
+
+// partition runs the predicate function with each element in the slice, and splits the elements
+// by the results of the predicate function. It runs the predicate functions one at a time if
+// isSeries is true, or runs them with the specified concurrency otherwise.
+func partition[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	isSeries bool,
+	fn func(context.Context, T) (bool, error),
+) ([]T, []T, error) {
+	var ret []bool
+	var err error
+	if isSeries {
+		ret, err = mapSeries(parent, in, fn)
+	} else {
+		ret, err = mapSlice(parent, in, concurrency, fn)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched := make([]T, 0, len(in))
+	unmatched := make([]T, 0, len(in))
+	for i, v := range in {
+		if ret[i] {
+			matched = append(matched, v)
+		} else {
+			unmatched = append(unmatched, v)
+		}
+	}
+
+	return matched, unmatched, nil
+}
@@ -0,0 +1,238 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func isEven(ctx context.Context, n int) (bool, error) {
+	return n%2 == 0, nil
+}
+
+func TestFilter(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{5, 4, 3, 2, 1}
+	out, err := async.Filter(in, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n*10) * time.Millisecond)
+		return n%2 == 1, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{5, 3, 1})
+
+	out, err = async.Filter([]int{}, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{})
+
+	out, err = async.FilterWithContext(context.Background(), []int{1, 2, 3, 4}, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{2, 4})
+}
+
+func TestFilterWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Filter[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		async.RejectSeries[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestFilterWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, err := async.Filter([]int{1, 2, 3}, func(ctx context.Context, n int) (bool, error) {
+		if n == 2 {
+			return false, expectedErr
+		}
+		return true, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+	a.NilNow(out)
+
+	_, err = async.Filter([]int{1, 2, 3}, func(ctx context.Context, n int) (bool, error) {
+		if n == 3 {
+			panic("expected panic")
+		}
+		return true, nil
+	})
+	a.EqualNow(err.Error(), "function 2 error: expected panic")
+}
+
+func TestFilterLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := []int{1, 2, 3, 4, 5, 6}
+	out, err := async.FilterLimit(in, 2, func(ctx context.Context, n int) (bool, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return n > 3, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{4, 5, 6})
+	a.EqualNow(maxRunning.Load(), 2)
+
+	out, err = async.FilterLimitWithContext(context.Background(), in, 3, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{2, 4, 6})
+}
+
+func TestFilterSeries(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	calls := make([]int, 0, 3)
+
+	out, err := async.FilterSeries([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (bool, error) {
+		calls = append(calls, n)
+		if n == 3 {
+			return false, expectedErr
+		}
+		return true, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: expected error")
+	a.NilNow(out)
+	a.EqualNow(calls, []int{1, 2, 3})
+
+	out, err = async.FilterSeriesWithContext(context.Background(), []int{1, 2, 3}, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{2})
+}
+
+func TestFilterWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	in := []int{1, 2}
+	_, err := async.FilterWithContext(ctx, in, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return true, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleFilter() {
+	out, err := async.Filter([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (bool, error) {
+		return n%2 == 0, nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [2 4]
+	// <nil>
+}
+
+func TestReject(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	in := []int{1, 2, 3, 4, 5}
+
+	out, err := async.Reject(in, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+
+	out, err = async.RejectWithContext(ctx, in, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+
+	out, err = async.RejectLimit(in, 2, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+
+	out, err = async.RejectLimitWithContext(ctx, in, 2, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+
+	out, err = async.RejectSeries(in, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+
+	out, err = async.RejectSeriesWithContext(ctx, in, isEven)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 3, 5})
+}
+
+func TestPartition(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	in := []int{1, 2, 3, 4, 5}
+
+	pass, fail, err := async.Partition(in, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+
+	pass, fail, err = async.PartitionWithContext(ctx, in, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+
+	pass, fail, err = async.PartitionLimit(in, 2, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+
+	pass, fail, err = async.PartitionLimitWithContext(ctx, in, 2, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+
+	pass, fail, err = async.PartitionSeries(in, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+
+	pass, fail, err = async.PartitionSeriesWithContext(ctx, in, isEven)
+	a.NilNow(err)
+	a.EqualNow(pass, []int{2, 4})
+	a.EqualNow(fail, []int{1, 3, 5})
+}
+
+func TestPartitionWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	pass, fail, err := async.Partition([]int{1, 2}, func(ctx context.Context, n int) (bool, error) {
+		return false, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.NilNow(pass)
+	a.NilNow(fail)
+}
+
+func ExamplePartition() {
+	in := []int{1, 2, 3, 4}
+	pass, fail, err := async.Partition(in, func(ctx context.Context, n int) (bool, error) {
+		return n%2 == 0, nil
+	})
+	fmt.Println(pass)
+	fmt.Println(fail)
+	fmt.Println(err)
+	// Output:
+	// [2 4]
+	// [1 3]
+	// <nil>
+}
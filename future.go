@@ -0,0 +1,167 @@
+package async
+
+import "context"
+
+// Future is the eventual result of a function that runs in the background, it's similar to the
+// JavaScript Promise object. The result can be got by the Await method, or be chained by the Then,
+// Catch, and Finally methods.
+//
+// The Await method of the future has the same signature with the typed function, so it can be
+// used with the combinators like AllOf, AllCompletedOf and RaceOf.
+//
+//	f1 := async.NewFuture(func(ctx context.Context) (int, error) {
+//	  return 1, nil
+//	})
+//	f2 := async.NewFuture(func(ctx context.Context) (int, error) {
+//	  return 2, nil
+//	})
+//	out, err := async.AllOf(f1.Await, f2.Await)
+//	// out: []int{1, 2}
+//	// err: <nil>
+type Future[T any] struct {
+	// ctx is the context that passes to the function and the chained functions.
+	ctx context.Context
+	// done is the channel that will be closed after the function is finished.
+	done chan struct{}
+	// out is the return value of the function.
+	out T
+	// err is the error that the function returned or panicked.
+	err error
+}
+
+// NewFuture runs the function in the background, and returns a future to get the result of the
+// function. The panic of the function will be caught and set as the error of the future.
+//
+//	f := async.NewFuture(func(ctx context.Context) (int, error) {
+//	  time.Sleep(100 * time.Millisecond)
+//	  return 1, nil
+//	})
+//	out, err := f.Await(context.Background())
+//	// out: 1
+//	// err: <nil>
+func NewFuture[T any](fn func(context.Context) (T, error)) *Future[T] {
+	return newFuture(context.Background(), fn)
+}
+
+// NewFutureWithContext runs the function with the specified context in the background, and returns
+// a future to get the result of the function. The context will also pass to the functions that are
+// chained by the Then, Catch, and Finally methods.
+func NewFutureWithContext[T any](
+	ctx context.Context,
+	fn func(context.Context) (T, error),
+) *Future[T] {
+	return newFuture(ctx, fn)
+}
+
+// newFuture creates a future and runs the function in the background.
+func newFuture[T any](ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	validateTypedFuncs(fn)
+
+	f := &Future[T]{
+		ctx:  getContext(ctx),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(f.done)
+		f.out, f.err = invokeTypedFn(fn, f.ctx)
+	}()
+
+	return f
+}
+
+// Await waits for the function to finish and returns its result and error. If the context is done
+// (canceled or timeout) before the function finished, it will return a context canceled error, and
+// the function will keep running in the background.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	ctx = getContext(ctx)
+
+	select {
+	case <-f.done:
+		return f.out, f.err
+	case <-ctx.Done():
+		var out T
+		return out, ErrContextCanceled
+	}
+}
+
+// Done returns a channel that will be closed after the function is finished.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Then returns a new future that runs the function with the result of the current future after
+// the current future succeeded. The new future will be failed with the same error without running
+// the function if the current future failed.
+//
+// To chain a function that returns a different type, use the package-level Then function.
+func (f *Future[T]) Then(fn func(context.Context, T) (T, error)) *Future[T] {
+	return Then(f, fn)
+}
+
+// Catch returns a new future that runs the function with the error of the current future after
+// the current future failed, and the function's return values will be the result of the new
+// future. The new future will have the same result as the current future if it succeeded.
+func (f *Future[T]) Catch(fn func(context.Context, error) (T, error)) *Future[T] {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	return newFuture(f.ctx, func(ctx context.Context) (T, error) {
+		out, err := f.wait()
+		if err == nil {
+			return out, nil
+		}
+		return fn(ctx, err)
+	})
+}
+
+// Finally returns a new future that runs the function after the current future is finished,
+// whether it succeeded or failed. The new future will have the same result as the current future,
+// unless the function returns an error or panics.
+func (f *Future[T]) Finally(fn func(context.Context) error) *Future[T] {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	return newFuture(f.ctx, func(ctx context.Context) (T, error) {
+		out, err := f.wait()
+		if finErr := fn(ctx); finErr != nil {
+			return out, finErr
+		}
+		return out, err
+	})
+}
+
+// wait waits for the function to finish and returns its result and error.
+func (f *Future[T]) wait() (T, error) {
+	<-f.done
+	return f.out, f.err
+}
+
+// Then returns a new future that runs the function with the result of the future after the future
+// succeeded, and the function can return a value in a different type. The new future will be
+// failed with the same error without running the function if the future failed.
+//
+//	f := async.NewFuture(func(ctx context.Context) (int, error) {
+//	  return 1, nil
+//	})
+//	out, err := async.Then(f, func(ctx context.Context, n int) (string, error) {
+//	  return strconv.Itoa(n), nil
+//	}).Await(context.Background())
+//	// out: "1"
+//	// err: <nil>
+func Then[T, R any](f *Future[T], fn func(context.Context, T) (R, error)) *Future[R] {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	return newFuture(f.ctx, func(ctx context.Context) (R, error) {
+		out, err := f.wait()
+		if err != nil {
+			var ret R
+			return ret, err
+		}
+		return fn(ctx, out)
+	})
+}
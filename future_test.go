@@ -0,0 +1,256 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestFuture(t *testing.T) {
+	a := assert.New(t)
+
+	start := time.Now()
+	f := async.NewFuture(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	})
+	a.LtNow(time.Since(start), 50*time.Millisecond)
+
+	out, err := f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+	a.GteNow(time.Since(start), 50*time.Millisecond)
+
+	// await a finished future again
+	out, err = f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+}
+
+func TestFutureWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	f := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	})
+	_, err := f.Await(context.Background())
+	a.EqualNow(err, expectedErr)
+
+	f = async.NewFuture(func(ctx context.Context) (int, error) {
+		panic(expectedErr)
+	})
+	_, err = f.Await(context.Background())
+	a.EqualNow(err, expectedErr)
+}
+
+func TestFutureWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewFuture[int](nil)
+	}, async.ErrNotFunction)
+}
+
+func TestFutureWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	f := async.NewFutureWithContext(ctx, func(ctx context.Context) (int, error) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return 1, nil
+		}
+	})
+
+	_, err := f.Await(context.Background())
+	a.IsErrorNow(err, context.DeadlineExceeded)
+}
+
+func TestFutureAwaitWithTimedOutContext(t *testing.T) {
+	a := assert.New(t)
+
+	f := async.NewFuture(func(ctx context.Context) (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	})
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	out, err := f.Await(ctx)
+	a.EqualNow(err, async.ErrContextCanceled)
+	a.EqualNow(out, 0)
+
+	<-f.Done()
+	out, err = f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+}
+
+func TestFutureThen(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, err := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).Then(func(ctx context.Context, n int) (int, error) {
+		return n + 1, nil
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+
+	isCalled := false
+	_, err = async.NewFuture(func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	}).Then(func(ctx context.Context, n int) (int, error) {
+		isCalled = true
+		return n + 1, nil
+	}).Await(context.Background())
+	a.EqualNow(err, expectedErr)
+	a.NotTrueNow(isCalled)
+
+	_, err = async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).Then(func(ctx context.Context, n int) (int, error) {
+		panic(expectedErr)
+	}).Await(context.Background())
+	a.EqualNow(err, expectedErr)
+}
+
+func TestThen(t *testing.T) {
+	a := assert.New(t)
+
+	f := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	out, err := async.Then(f, func(ctx context.Context, n int) (string, error) {
+		return strconv.Itoa(n), nil
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, "1")
+}
+
+func TestFutureCatch(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, err := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	}).Catch(func(ctx context.Context, err error) (int, error) {
+		if errors.Is(err, expectedErr) {
+			return -1, nil
+		}
+		return 0, err
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, -1)
+
+	isCalled := false
+	out, err = async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).Catch(func(ctx context.Context, err error) (int, error) {
+		isCalled = true
+		return -1, nil
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+	a.NotTrueNow(isCalled)
+}
+
+func TestFutureFinally(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	finallyErr := errors.New("finally error")
+
+	cnt := 0
+	out, err := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).Finally(func(ctx context.Context) error {
+		cnt++
+		return nil
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+	a.EqualNow(cnt, 1)
+
+	_, err = async.NewFuture(func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	}).Finally(func(ctx context.Context) error {
+		cnt++
+		return nil
+	}).Await(context.Background())
+	a.EqualNow(err, expectedErr)
+	a.EqualNow(cnt, 2)
+
+	_, err = async.NewFuture(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).Finally(func(ctx context.Context) error {
+		return finallyErr
+	}).Await(context.Background())
+	a.EqualNow(err, finallyErr)
+}
+
+func TestFutureWithCombinators(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	f1 := async.NewFuture(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	})
+	f2 := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+	f3 := async.NewFuture(func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	})
+
+	out, err := async.AllOf(f1.Await, f2.Await)
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 2})
+
+	out, err = async.AllCompletedOf(f1.Await, f2.Await, f3.Await)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: expected error")
+	a.EqualNow(out, []int{1, 2, 0})
+
+	f4 := async.NewFuture(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 4, nil
+	})
+	ret, index, err := async.RaceOf(f4.Await, f2.Await)
+	a.NilNow(err)
+	a.EqualNow(index, 1)
+	a.EqualNow(ret, 2)
+
+	anyOut, err := async.All(f1.Await, f2.Await)
+	a.NilNow(err)
+	a.EqualNow(anyOut, [][]any{{1, nil}, {2, nil}})
+}
+
+func ExampleFuture() {
+	f := async.NewFuture(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	}).Then(func(ctx context.Context, n int) (int, error) {
+		return n + 1, nil
+	})
+
+	out, err := f.Await(context.Background())
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// 2
+	// <nil>
+}
@@ -0,0 +1,265 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Graph is a tool to build the tasks as a directed acyclic graph, and run the tasks by their
+// dependencies with the specific concurrency, default no concurrency limitation. Every node will be
+// run as soon as all of its dependencies are finished, and it receives the return values of its
+// dependencies (without the last error) as the parameters in the order of the dependencies.
+//
+// Unlike Auto, the graph checks the types of the parameters of every node's function are matched
+// to the return values of its dependencies before running. The graph can also be exported to the
+// DOT or the Mermaid format to visualize the pipeline.
+//
+//	g := new(async.Graph).
+//	  AddNode("user", func(ctx context.Context) (int, error) {
+//	    return GetUserID(ctx)
+//	  }).
+//	  AddNode("orders", func(ctx context.Context, id int) ([]int, error) {
+//	    return GetOrders(ctx, id)
+//	  }, "user")
+//	out, err := g.Run()
+//	// out["orders"]: []any{[]int{...}, nil}
+type Graph struct {
+	concurrency int
+	ctx         context.Context
+	locker      sync.Mutex
+	// names is the names of the nodes in the order that they were added.
+	names []string
+	// nodes is the nodes of the graph by their names.
+	nodes map[string]AutoTask
+}
+
+// WithConcurrency sets the number of concurrency limitation.
+func (g *Graph) WithConcurrency(concurrency int) *Graph {
+	if concurrency < 0 {
+		panic(ErrInvalidConcurrency)
+	}
+
+	g.concurrency = concurrency
+
+	return g
+}
+
+// WithContext sets the context that passes to the nodes.
+func (g *Graph) WithContext(ctx context.Context) *Graph {
+	g.ctx = ctx
+
+	return g
+}
+
+// AddNode adds a node with the function and the names of its dependencies into the graph. The
+// dependencies can be added after the node, and they'll be checked when the graph is validated or
+// run. It'll panic if the function is nil or not a function, or the name has been added.
+func (g *Graph) AddNode(name string, fn AsyncFn, deps ...string) *Graph {
+	validateAsyncFuncs(fn)
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if _, ok := g.nodes[name]; ok {
+		panic(ErrDuplicateNode)
+	} else if g.nodes == nil {
+		g.nodes = make(map[string]AutoTask)
+	}
+
+	g.names = append(g.names, name)
+	g.nodes[name] = AutoTask{
+		Dependencies: append([]string(nil), deps...),
+		Fn:           fn,
+	}
+
+	return g
+}
+
+// Validate checks the nodes of the graph. It returns a task error that wraps ErrMissingDependency
+// if a node depends on a node that does not exist, ErrCyclicDependency if the dependencies have a
+// cycle, or ErrUnmatchedParam if the parameters of a node's function are not matched to the
+// return values of its dependencies.
+func (g *Graph) Validate() error {
+	names, nodes := g.getNodes()
+	return validateGraphNodes(names, nodes)
+}
+
+// Run validates and runs the nodes of the graph by their dependencies, and returns the return
+// values of all nodes by their names. If any node returns an error or panics, it will immediately
+// return the results of the finished nodes and a task error that contains the node's name, and
+// send a cancel signal to all other nodes by context.
+func (g *Graph) Run() (map[string][]any, error) {
+	names, nodes := g.getNodes()
+	if err := validateGraphNodes(names, nodes); err != nil {
+		return nil, err
+	}
+
+	return runAutoTasks(g.ctx, names, nodes, g.concurrency)
+}
+
+// DOT returns the graph in the DOT language of Graphviz, every edge points from the dependency to
+// the node that depends on it.
+//
+//	digraph {
+//	  "user";
+//	  "orders";
+//	  "user" -> "orders";
+//	}
+func (g *Graph) DOT() string {
+	names, nodes := g.getNodes()
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	buf := strings.Builder{}
+
+	buf.WriteString("digraph {\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "  \"%s\";\n", escaper.Replace(name))
+	}
+	for _, name := range names {
+		for _, dep := range nodes[name].Dependencies {
+			fmt.Fprintf(&buf, "  \"%s\" -> \"%s\";\n", escaper.Replace(dep), escaper.Replace(name))
+		}
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// Mermaid returns the graph in the Mermaid flowchart syntax, every edge points from the dependency
+// to the node that depends on it. The nodes are identified by the order that they were added, and
+// labeled by their names.
+//
+//	graph TD
+//	  n0["user"]
+//	  n1["orders"]
+//	  n0 --> n1
+func (g *Graph) Mermaid() string {
+	names, nodes := g.getNodes()
+	escaper := strings.NewReplacer(`"`, "#quot;")
+	ids := make(map[string]string, len(names))
+	buf := strings.Builder{}
+
+	writeNode := func(name string) {
+		ids[name] = fmt.Sprintf("n%d", len(ids))
+		fmt.Fprintf(&buf, "  %s[\"%s\"]\n", ids[name], escaper.Replace(name))
+	}
+
+	buf.WriteString("graph TD\n")
+	for _, name := range names {
+		writeNode(name)
+	}
+	for _, name := range names {
+		for _, dep := range nodes[name].Dependencies {
+			if _, ok := ids[dep]; !ok {
+				// the dependency does not exist, but it should still be shown in the graph.
+				writeNode(dep)
+			}
+		}
+	}
+	for _, name := range names {
+		for _, dep := range nodes[name].Dependencies {
+			fmt.Fprintf(&buf, "  %s --> %s\n", ids[dep], ids[name])
+		}
+	}
+
+	return buf.String()
+}
+
+// getNodes returns a snapshot of the names and the nodes of the graph.
+func (g *Graph) getNodes() ([]string, map[string]AutoTask) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	names := append([]string(nil), g.names...)
+	nodes := make(map[string]AutoTask, len(g.nodes))
+	for name, node := range g.nodes {
+		nodes[name] = node
+	}
+
+	return names, nodes
+}
+
+// validateGraphNodes checks the dependencies of the nodes, and checks the parameters of every
+// node's function are matched to the return values of its dependencies.
+func validateGraphNodes(names []string, nodes map[string]AutoTask) error {
+	if err := validateAutoTasks(names, nodes); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		node := nodes[name]
+		outs := make([]reflect.Type, 0, len(node.Dependencies))
+		for _, dep := range node.Dependencies {
+			outs = append(outs, getFuncOutTypes(reflect.TypeOf(nodes[dep].Fn))...)
+		}
+
+		if !isValidDependentFunc(outs, reflect.TypeOf(node.Fn)) {
+			return &taskError{
+				name: name,
+				err:  ErrUnmatchedParam,
+			}
+		}
+	}
+
+	return nil
+}
+
+// getFuncOutTypes returns the types of the function's return values without the last error.
+func getFuncOutTypes(fn reflect.Type) []reflect.Type {
+	numOut := fn.NumOut()
+	if isFuncReturnsError(fn) {
+		numOut--
+	}
+
+	outs := make([]reflect.Type, 0, numOut)
+	for i := 0; i < numOut; i++ {
+		outs = append(outs, fn.Out(i))
+	}
+
+	return outs
+}
+
+// isValidDependentFunc checks the parameters of the function are matched to the return values of
+// its dependencies like isValidNextFunc, the context parameter will be skipped if the function
+// takes a context as the first parameter.
+func isValidDependentFunc(outs []reflect.Type, fn reflect.Type) bool {
+	isTakeContext, _ := isFuncTakesContexts(fn)
+	numIn := fn.NumIn()
+	i := 0
+	j := 0
+
+	if isTakeContext {
+		if len(outs) > 0 && isContextType(outs[0]) {
+			i++
+		}
+		j++
+	}
+	if fn.IsVariadic() {
+		numIn--
+	}
+	if len(outs)-i < numIn-j {
+		return false
+	}
+
+	for j < numIn {
+		if outs[i] != fn.In(j) {
+			return false
+		}
+		i++
+		j++
+	}
+
+	if fn.IsVariadic() {
+		// the rest of the return values will be passed as the variadic parameters.
+		elem := fn.In(numIn).Elem()
+		for ; i < len(outs); i++ {
+			if outs[i] != elem {
+				return false
+			}
+		}
+	}
+
+	return true
+}
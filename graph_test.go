@@ -0,0 +1,256 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestGraph(t *testing.T) {
+	a := assert.New(t)
+
+	g := new(async.Graph).
+		AddNode("c", func(ctx context.Context, n int, s string) (string, error) {
+			return fmt.Sprintf("%s%d", s, n), nil
+		}, "a", "b").
+		AddNode("a", func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).
+		AddNode("b", func() string {
+			return "b"
+		}).
+		AddNode("d", func(vals ...string) int {
+			return len(vals)
+		}, "b", "c")
+	a.NilNow(g.Validate())
+
+	out, err := g.Run()
+	a.NilNow(err)
+	a.EqualNow(len(out), 4)
+	a.EqualNow(out["a"], []any{1, nil})
+	a.EqualNow(out["b"], []any{"b"})
+	a.EqualNow(out["c"], []any{"b1", nil})
+	a.EqualNow(out["d"], []any{2})
+}
+
+func TestGraphWithEmptyNodes(t *testing.T) {
+	a := assert.New(t)
+
+	g := new(async.Graph)
+	a.NilNow(g.Validate())
+
+	out, err := g.Run()
+	a.NilNow(err)
+	a.EqualNow(len(out), 0)
+}
+
+func TestGraphAddInvalidNode(t *testing.T) {
+	a := assert.New(t)
+	g := new(async.Graph)
+
+	a.PanicOfNow(func() {
+		g.AddNode("a", nil)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		g.AddNode("a", 1)
+	}, async.ErrNotFunction)
+
+	g.AddNode("a", func() {})
+	a.PanicOfNow(func() {
+		g.AddNode("a", func() {})
+	}, async.ErrDuplicateNode)
+}
+
+func TestGraphValidate(t *testing.T) {
+	a := assert.New(t)
+
+	err := new(async.Graph).
+		AddNode("a", func() {}).
+		AddNode("b", func() {}, "a", "c").
+		Validate()
+	a.IsErrorNow(err, async.ErrMissingDependency)
+	a.EqualNow(err.Error(), "task b error: missing dependency")
+
+	err = new(async.Graph).
+		AddNode("a", func() {}, "b").
+		AddNode("b", func() {}, "a").
+		Validate()
+	a.IsErrorNow(err, async.ErrCyclicDependency)
+
+	err = new(async.Graph).
+		AddNode("a", func() (int, error) {
+			return 1, nil
+		}).
+		AddNode("b", func(s string) {}, "a").
+		Validate()
+	a.IsErrorNow(err, async.ErrUnmatchedParam)
+	a.EqualNow(err.Error(), "task b error: parameters are unmatched")
+
+	err = new(async.Graph).
+		AddNode("a", func() int {
+			return 1
+		}).
+		AddNode("b", func(ctx context.Context, x, y int) {}, "a").
+		Validate()
+	a.IsErrorNow(err, async.ErrUnmatchedParam)
+
+	err = new(async.Graph).
+		AddNode("a", func() (int, string) {
+			return 1, "a"
+		}).
+		AddNode("b", func(vals ...int) {}, "a").
+		Validate()
+	a.IsErrorNow(err, async.ErrUnmatchedParam)
+
+	_, err = new(async.Graph).
+		AddNode("a", func() {}, "a").
+		Run()
+	a.IsErrorNow(err, async.ErrCyclicDependency)
+}
+
+func TestGraphWithConcurrency(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	fn := func() {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	g := new(async.Graph).WithConcurrency(2)
+	for i := 0; i < 5; i++ {
+		g.AddNode(fmt.Sprintf("node%d", i), fn)
+	}
+	g.AddNode("last", fn, "node0", "node1", "node2", "node3", "node4")
+
+	start := time.Now()
+	_, err := g.Run()
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(maxRunning.Load(), 2)
+	a.GteNow(dur, 80*time.Millisecond)
+	a.LtNow(dur, 120*time.Millisecond)
+
+	a.PanicOfNow(func() {
+		g.WithConcurrency(-1)
+	}, async.ErrInvalidConcurrency)
+}
+
+func TestGraphWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	isRun := atomic.Bool{}
+
+	out, err := new(async.Graph).
+		AddNode("a", func() int {
+			return 1
+		}).
+		AddNode("b", func(n int) error {
+			return expectedErr
+		}, "a").
+		AddNode("c", func() {
+			isRun.Store(true)
+		}, "b").
+		Run()
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "task b error: expected error")
+	a.EqualNow(out["a"], []any{1})
+	a.NotTrueNow(isRun.Load())
+}
+
+func TestGraphWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	_, err := new(async.Graph).
+		WithContext(ctx).
+		AddNode("a", func(ctx context.Context) error {
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		}).
+		Run()
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func TestGraphDOT(t *testing.T) {
+	a := assert.New(t)
+
+	g := new(async.Graph).
+		AddNode("a", func() {}).
+		AddNode(`b "1"`, func() {}, "a").
+		AddNode("c", func() {}, "a", `b "1"`)
+	a.EqualNow(g.DOT(), `digraph {
+  "a";
+  "b \"1\"";
+  "c";
+  "a" -> "b \"1\"";
+  "a" -> "c";
+  "b \"1\"" -> "c";
+}
+`)
+
+	a.EqualNow(new(async.Graph).DOT(), "digraph {\n}\n")
+}
+
+func TestGraphMermaid(t *testing.T) {
+	a := assert.New(t)
+
+	g := new(async.Graph).
+		AddNode("a", func() {}).
+		AddNode(`b "1"`, func() {}, "a").
+		AddNode("c", func() {}, "a", `b "1"`, "d")
+	a.EqualNow(g.Mermaid(), `graph TD
+  n0["a"]
+  n1["b #quot;1#quot;"]
+  n2["c"]
+  n3["d"]
+  n0 --> n1
+  n0 --> n2
+  n1 --> n2
+  n3 --> n2
+`)
+}
+
+func ExampleGraph() {
+	g := new(async.Graph).
+		AddNode("x", func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).
+		AddNode("y", func(ctx context.Context) (int, error) {
+			return 2, nil
+		}).
+		AddNode("sum", func(ctx context.Context, x, y int) (int, error) {
+			return x + y, nil
+		}, "x", "y")
+
+	out, err := g.Run()
+	fmt.Println(out["sum"][0])
+	fmt.Println(err)
+	fmt.Print(g.Mermaid())
+	// Output:
+	// 3
+	// <nil>
+	// graph TD
+	//   n0["x"]
+	//   n1["y"]
+	//   n2["sum"]
+	//   n0 --> n2
+	//   n1 --> n2
+}
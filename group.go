@@ -0,0 +1,110 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a tool to deduplicate the concurrent calls with the same key, it's similar to the
+// singleflight package. The function will be run only once for the calls with the same key at the
+// same time, and the return values and the error will be shared by all of the callers. The zero
+// value of Group is ready to use.
+//
+// The function receives the group's context instead of the callers' contexts, so a caller that
+// stops waiting for the result will not cancel the shared call.
+//
+//	g := new(async.Group)
+//	out, shared, err := g.Do(ctx, "user:1", func(ctx context.Context) (User, error) {
+//	  return GetUser(ctx, 1)
+//	})
+type Group struct {
+	ctx    context.Context
+	locker sync.Mutex
+	// calls is the in-flight calls by their keys.
+	calls map[string]*groupCall
+}
+
+// groupCall is an in-flight or finished call of the group.
+type groupCall struct {
+	// done is the channel that will be closed after the function is finished.
+	done chan struct{}
+	// dups is the number of the callers that joined the call after it was started.
+	dups int
+	// out is the return values of the function.
+	out []any
+	// err is the error that the function returned or panicked.
+	err error
+}
+
+// WithContext sets the context that passes to the functions.
+func (g *Group) WithContext(ctx context.Context) *Group {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.ctx = ctx
+
+	return g
+}
+
+// Do runs the function with the key, and returns its return values and error. If there is an
+// in-flight call with the same key, it will wait for the in-flight call and return its result
+// instead of running the function again. The shared flag indicates whether the result was given
+// to multiple callers.
+//
+// It returns ErrContextCanceled if the context is done (canceled or timeout) before the function
+// finished, and the function will keep running for other callers. It'll panic if the function is
+// nil or not a function.
+func (g *Group) Do(ctx context.Context, key string, fn AsyncFn) ([]any, bool, error) {
+	validateAsyncFuncs(fn)
+	ctx = getContext(ctx)
+
+	g.locker.Lock()
+	call, ok := g.calls[key]
+	if ok {
+		call.dups++
+	} else {
+		call = &groupCall{
+			done: make(chan struct{}),
+		}
+		if g.calls == nil {
+			g.calls = make(map[string]*groupCall)
+		}
+		g.calls[key] = call
+
+		go g.run(getContext(g.ctx), key, call, fn)
+	}
+	g.locker.Unlock()
+
+	select {
+	case <-call.done:
+		return call.out, call.dups > 0, call.err
+	case <-ctx.Done():
+		return nil, ok, ErrContextCanceled
+	}
+}
+
+// Forget forgets the in-flight call of the key, and the further calls with the key will run the
+// function again instead of waiting for the in-flight call. The callers that are waiting for the
+// in-flight call will still receive its result.
+func (g *Group) Forget(key string) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	delete(g.calls, key)
+}
+
+// run runs the function of the call, and removes the call from the group after it finished.
+func (g *Group) run(ctx context.Context, key string, call *groupCall, fn AsyncFn) {
+	out, err := invokeAsyncFn(fn, ctx, nil)
+
+	g.locker.Lock()
+	call.out = out
+	call.err = err
+	// the call may be forgotten and replaced by a new call.
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.locker.Unlock()
+
+	close(call.done)
+}
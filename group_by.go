@@ -0,0 +1,81 @@
+package async
+
+import "context"
+
+// GroupBy runs the function with each element in the slice asynchronously to get the key of the
+// element, and groups the elements by the keys. The elements in each group keep the same order as
+// they are in the slice. If the function returns an error or panics with any element, it will
+// immediately return an execution error that the index is the element's index in the slice, and
+// send a cancel signal to all other functions by context.
+//
+//	users := []int{1, 2, 3}
+//	out, err := async.GroupBy(users, func(ctx context.Context, id int) (string, error) {
+//	  return GetUserRegion(id)
+//	})
+//	// out: map[string][]int{"us": {1, 3}, "eu": {2}}
+//	// err: <nil>
+func GroupBy[T any, K comparable](
+	in []T,
+	fn func(context.Context, T) (K, error),
+) (map[K][]T, error) {
+	return groupBy(context.Background(), in, 0, fn)
+}
+
+// GroupByWithContext runs the function with each element in the slice asynchronously with the
+// specified context to get the key of the element, and groups the elements by the keys. If the
+// function returns an error or panics with any element, it will immediately return an execution
+// error, and send a cancel signal to all other functions by context.
+func GroupByWithContext[T any, K comparable](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (K, error),
+) (map[K][]T, error) {
+	return groupBy(ctx, in, 0, fn)
+}
+
+// GroupByLimit runs the function with each element in the slice asynchronously with the specified
+// concurrency limit to get the key of the element, and groups the elements by the keys. If the
+// function returns an error or panics with any element, it will immediately return an execution
+// error, and send a cancel signal to all other functions by context.
+func GroupByLimit[T any, K comparable](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) (map[K][]T, error) {
+	return groupBy(context.Background(), in, concurrency, fn)
+}
+
+// GroupByLimitWithContext runs the function with each element in the slice asynchronously with
+// the specified context and the concurrency limit to get the key of the element, and groups the
+// elements by the keys. If the function returns an error or panics with any element, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func GroupByLimitWithContext[T any, K comparable](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) (map[K][]T, error) {
+	return groupBy(ctx, in, concurrency, fn)
+}
+
+// groupBy gets the keys of the elements with the specified concurrency, and groups the elements by
+// the keys.
+func groupBy[T any, K comparable](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) (map[K][]T, error) {
+	keys, err := mapSlice(parent, in, concurrency, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[K][]T)
+	for i, key := range keys {
+		out[key] = append(out[key], in[i])
+	}
+
+	return out, nil
+}
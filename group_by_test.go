@@ -0,0 +1,127 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestGroupBy(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{5, 4, 3, 2, 1}
+	out, err := async.GroupBy(in, func(ctx context.Context, n int) (string, error) {
+		time.Sleep(time.Duration(n*10) * time.Millisecond)
+		if n%2 == 0 {
+			return "even", nil
+		}
+		return "odd", nil
+	})
+	a.NilNow(err)
+	a.EqualNow(len(out), 2)
+	a.EqualNow(out["even"], []int{4, 2})
+	a.EqualNow(out["odd"], []int{5, 3, 1})
+
+	out, err = async.GroupBy([]int{}, func(ctx context.Context, n int) (string, error) {
+		return "", nil
+	})
+	a.NilNow(err)
+	a.EqualNow(len(out), 0)
+}
+
+func TestGroupByWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.GroupBy[int, int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestGroupByWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, err := async.GroupBy([]int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+		if n == 2 {
+			return 0, expectedErr
+		}
+		return n, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+	a.NilNow(out)
+}
+
+func TestGroupByLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := []string{"a", "bb", "cc", "d", "eee"}
+	out, err := async.GroupByLimit(in, 2, func(ctx context.Context, s string) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		return len(s), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(len(out), 3)
+	a.EqualNow(out[1], []string{"a", "d"})
+	a.EqualNow(out[2], []string{"bb", "cc"})
+	a.EqualNow(out[3], []string{"eee"})
+	a.EqualNow(maxRunning.Load(), 2)
+
+	out, err = async.GroupByLimitWithContext(
+		context.Background(),
+		in,
+		1,
+		func(ctx context.Context, s string) (int, error) {
+			return len(s) % 2, nil
+		},
+	)
+	a.NilNow(err)
+	a.EqualNow(len(out), 2)
+	a.EqualNow(out[0], []string{"bb", "cc"})
+	a.EqualNow(out[1], []string{"a", "d", "eee"})
+}
+
+func TestGroupByWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	in := []int{1, 2}
+	_, err := async.GroupByWithContext(ctx, in, func(ctx context.Context, n int) (int, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return n, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleGroupBy() {
+	in := []int{1, 2, 3, 4, 5}
+	out, err := async.GroupBy(in, func(ctx context.Context, n int) (bool, error) {
+		return n%2 == 0, nil
+	})
+	fmt.Println(out[true])
+	fmt.Println(out[false])
+	fmt.Println(err)
+	// Output:
+	// [2 4]
+	// [1 3 5]
+	// <nil>
+}
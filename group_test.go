@@ -0,0 +1,192 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestGroup(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	g := new(async.Group)
+
+	fn := func() int32 {
+		time.Sleep(50 * time.Millisecond)
+		return cnt.Add(1)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, shared, err := g.Do(context.Background(), "key", fn)
+			a.NilNow(err)
+			a.TrueNow(shared)
+			a.EqualNow(out, []any{int32(1)})
+		}()
+	}
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 1)
+
+	// runs the function again after the previous call finished.
+	out, shared, err := g.Do(context.Background(), "key", fn)
+	a.NilNow(err)
+	a.NotTrueNow(shared)
+	a.EqualNow(out, []any{int32(2)})
+}
+
+func TestGroupWithDifferentKeys(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	g := new(async.Group)
+
+	wg := sync.WaitGroup{}
+	for _, key := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			out, shared, err := g.Do(context.Background(), key, func() string {
+				cnt.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				return key
+			})
+			a.NilNow(err)
+			a.NotTrueNow(shared)
+			a.EqualNow(out, []any{key})
+		}(key)
+	}
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 3)
+}
+
+func TestGroupWithInvalidFunction(t *testing.T) {
+	a := assert.New(t)
+	g := new(async.Group)
+
+	a.PanicOfNow(func() {
+		g.Do(context.Background(), "key", nil)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		g.Do(context.Background(), "key", 1)
+	}, async.ErrNotFunction)
+}
+
+func TestGroupWithError(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	g := new(async.Group)
+
+	out, shared, err := g.Do(context.Background(), "key", func() (int, error) {
+		return 0, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.NotTrueNow(shared)
+	a.EqualNow(out, []any{0, expectedErr})
+
+	_, _, err = g.Do(context.Background(), "key", func() {
+		panic("expected panic")
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "expected panic")
+}
+
+func TestGroupWaiterCanceled(t *testing.T) {
+	a := assert.New(t)
+	g := new(async.Group)
+	started := make(chan struct{})
+	isCanceled := atomic.Bool{}
+
+	fn := func(ctx context.Context) int {
+		close(started)
+		select {
+		case <-ctx.Done():
+			isCanceled.Store(true)
+		case <-time.After(30 * time.Millisecond):
+		}
+		return 1
+	}
+
+	ch := make(chan []any)
+	go func() {
+		out, shared, err := g.Do(context.Background(), "key", fn)
+		a.NilNow(err)
+		a.TrueNow(shared)
+		ch <- out
+	}()
+	<-started
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	out, shared, err := g.Do(ctx, "key", fn)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.TrueNow(shared)
+	a.NilNow(out)
+
+	// the shared call will not be canceled by the waiter.
+	a.EqualNow(<-ch, []any{1})
+	a.NotTrueNow(isCanceled.Load())
+}
+
+func TestGroupForget(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	g := new(async.Group)
+	started := make(chan struct{}, 2)
+
+	fn := func() int32 {
+		n := cnt.Add(1)
+		started <- struct{}{}
+		time.Sleep(20 * time.Millisecond)
+		return n
+	}
+
+	ch := make(chan []any)
+	go func() {
+		out, _, err := g.Do(context.Background(), "key", fn)
+		a.NilNow(err)
+		ch <- out
+	}()
+	<-started
+
+	g.Forget("key")
+	out, shared, err := g.Do(context.Background(), "key", fn)
+	a.NilNow(err)
+	a.NotTrueNow(shared)
+	a.EqualNow(out, []any{int32(2)})
+	a.EqualNow(<-ch, []any{int32(1)})
+	a.EqualNow(cnt.Load(), 2)
+}
+
+func TestGroupWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	//lint:ignore SA1029 for test case only
+	ctx := context.WithValue(context.Background(), "key", "value")
+	g := new(async.Group).WithContext(ctx)
+
+	out, _, err := g.Do(context.Background(), "key", func(ctx context.Context) string {
+		return ctx.Value("key").(string)
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []any{"value"})
+}
+
+func ExampleGroup() {
+	g := new(async.Group)
+
+	out, shared, err := g.Do(context.Background(), "key", func() (int, error) {
+		return 1, nil
+	})
+	fmt.Println(out, shared, err)
+	// Output:
+	// [1 <nil>] false <nil>
+}
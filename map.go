@@ -0,0 +1,147 @@
+package async
+
+import "context"
+
+// Map runs the function with each element in the slice asynchronously, and returns the results
+// in the same order as the elements in the slice. If the function returns an error or panics with
+// any element, it will immediately return an execution error that the index is the element's index
+// in the slice, and send a cancel signal to all other functions by context.
+//
+//	out, err := async.Map([]int{1, 2, 3}, func(ctx context.Context, n int) (string, error) {
+//	  return strconv.Itoa(n * 2), nil
+//	})
+//	// out: []string{"2", "4", "6"}
+//	// err: <nil>
+func Map[In, Out any](in []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return mapSlice(context.Background(), in, 0, fn)
+}
+
+// MapWithContext runs the function with each element in the slice asynchronously with the
+// specified context, and returns the results in the same order as the elements in the slice. If
+// the function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func MapWithContext[In, Out any](
+	ctx context.Context,
+	in []In,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	return mapSlice(ctx, in, 0, fn)
+}
+
+// MapLimit runs the function with each element in the slice asynchronously with the specified
+// concurrency limit, and returns the results in the same order as the elements in the slice. If
+// the function returns an error or panics with any element, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+//
+//	// Runs 2 functions at the same time.
+//	keys := []string{"a", "b", "c"}
+//	out, err := async.MapLimit(keys, 2, func(ctx context.Context, key string) (int, error) {
+//	  return Get(key)
+//	})
+func MapLimit[In, Out any](
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	return mapSlice(context.Background(), in, concurrency, fn)
+}
+
+// MapLimitWithContext runs the function with each element in the slice asynchronously with the
+// specified context and the concurrency limit, and returns the results in the same order as the
+// elements in the slice. If the function returns an error or panics with any element, it will
+// immediately return an execution error, and send a cancel signal to all other functions by
+// context.
+func MapLimitWithContext[In, Out any](
+	ctx context.Context,
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	return mapSlice(ctx, in, concurrency, fn)
+}
+
+// MapSeries runs the function with each element in the slice one at a time, and returns the
+// results in the same order as the elements in the slice. If the function returns an error or
+// panics with any element, it will return an execution error and no more functions are run.
+//
+//	out, err := async.MapSeries([]int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+//	  return n * 2, nil
+//	})
+//	// out: []int{2, 4, 6}
+//	// err: <nil>
+func MapSeries[In, Out any](in []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return mapSeries(context.Background(), in, fn)
+}
+
+// MapSeriesWithContext runs the function with each element in the slice one at a time with the
+// specified context, and returns the results in the same order as the elements in the slice. If
+// the function returns an error or panics with any element, it will return an execution error and
+// no more functions are run.
+func MapSeriesWithContext[In, Out any](
+	ctx context.Context,
+	in []In,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	return mapSeries(ctx, in, fn)
+}
+
+// mapSlice runs the function with each element in the slice with the specified concurrency.
+func mapSlice[In, Out any](
+	parent context.Context,
+	in []In,
+	concurrency int,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	return runTasks(paralleler, len(in), elementTask(in, fn))
+}
+
+// mapSeries runs the function with each element in the slice by the order.
+func mapSeries[In, Out any](
+	parent context.Context,
+	in []In,
+	fn func(context.Context, In) (Out, error),
+) ([]Out, error) {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	ctx := getContext(parent)
+	task := elementTask(in, fn)
+	out := make([]Out, len(in))
+
+	for i := 0; i < len(in); i++ {
+		ret, err := task(ctx, i)
+		out[i] = ret
+		if err != nil {
+			return out, &executionError{
+				index: i,
+				err:   err,
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// elementTask returns a taskFn that calls the function with the element in the slice by the index,
+// the panic of the function will be caught and returned as an error.
+func elementTask[In, Out any](in []In, fn func(context.Context, In) (Out, error)) taskFn[Out] {
+	return func(ctx context.Context, n int) (Out, error) {
+		return invokeTypedFn(func(ctx context.Context) (Out, error) {
+			return fn(ctx, in[n])
+		}, ctx)
+	}
+}
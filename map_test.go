@@ -0,0 +1,176 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestMap(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{5, 4, 3, 2, 1}
+	out, err := async.Map(in, func(ctx context.Context, n int) (string, error) {
+		time.Sleep(time.Duration(n*10) * time.Millisecond)
+		return strconv.Itoa(n * 2), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []string{"10", "8", "6", "4", "2"})
+}
+
+func TestMapWithEmptySlice(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.Map([]int{}, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{})
+
+	out, err = async.Map(nil, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{})
+}
+
+func TestMapWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Map[int, int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestMapWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	in := []string{"a", "b", "c", "d"}
+	out, err := async.Map(in, func(ctx context.Context, s string) (string, error) {
+		if s == "c" {
+			return "", expectedErr
+		}
+		return s + s, nil
+	})
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(len(out), 4)
+
+	var execErr async.ExecutionError
+	a.TrueNow(errors.As(err, &execErr))
+	a.EqualNow(execErr.Index(), 2)
+	a.EqualNow(err.Error(), "function 2 error: expected error")
+}
+
+func TestMapWithPanic(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := async.Map([]int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+		if n == 2 {
+			panic("expected panic")
+		}
+		return n, nil
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "function 1 error: expected panic")
+}
+
+func TestMapWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	_, err := async.MapWithContext(ctx, []int{1, 2}, func(ctx context.Context, n int) (int, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return n, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleMap() {
+	out, err := async.Map([]int{1, 2, 3}, func(ctx context.Context, n int) (string, error) {
+		return strconv.Itoa(n * 2), nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [2 4 6]
+	// <nil>
+}
+
+func TestMapLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := []int{1, 2, 3, 4, 5}
+	out, err := async.MapLimit(in, 2, func(ctx context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return n * n, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 4, 9, 16, 25})
+	a.EqualNow(maxRunning.Load(), 2)
+}
+
+func TestMapLimitWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{1, 2, 3}
+	//lint:ignore SA1012 for test case only
+	out, err := async.MapLimitWithContext(nil, in, 1, func(ctx context.Context, n int) (int, error) {
+		return n + 1, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{2, 3, 4})
+}
+
+func TestMapSeries(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	calls := make([]int, 0, 3)
+
+	out, err := async.MapSeries([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (int, error) {
+		calls = append(calls, n)
+		if n == 3 {
+			return 0, expectedErr
+		}
+		return n * 2, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: expected error")
+	a.EqualNow(out, []int{2, 4, 0, 0})
+	a.EqualNow(calls, []int{1, 2, 3})
+}
+
+func TestMapSeriesWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.MapSeriesWithContext(
+		context.Background(),
+		[]string{"a", "b"},
+		func(ctx context.Context, s string) (string, error) {
+			return s + "!", nil
+		},
+	)
+	a.NilNow(err)
+	a.EqualNow(out, []string{"a!", "b!"})
+}
@@ -105,3 +105,106 @@ func parallelCompleted(
 
 	return paralleler.RunCompleted()
 }
+
+// ParallelOf runs the typed functions asynchronously with the specified concurrency limitation.
+// It's the generic version of Parallel, the functions will be called directly without the
+// reflection, and it returns the results of the functions by the functions order. It will send a
+// cancel sign to context and terminate immediately if any function returns an error or panic, and
+// also returns an execution error to indicate the error.
+//
+// The number of concurrency must be greater than or equal to 0, and it means no concurrency
+// limitation if the number is 0.
+//
+//	// Run 2 functions asynchronously at the time.
+//	out, err := async.ParallelOf(2, func(ctx context.Context) (int, error) {
+//	  // Do something
+//	  return 1, nil
+//	}, func(ctx context.Context) (int, error) {
+//	  // Do something
+//	  return 2, nil
+//	} /* , ... */)
+//	// out: []int{1, 2}
+//	// err: <nil>
+func ParallelOf[T any](concurrency int, funcs ...func(context.Context) (T, error)) ([]T, error) {
+	return parallelOf(context.Background(), concurrency, funcs...)
+}
+
+// ParallelOfWithContext runs the typed functions asynchronously with the specified concurrency
+// limitation and the context. It will send a cancel sign to context and terminate immediately if
+// any function returns an error or panic, and also returns an execution error to indicate the
+// error. If the context was canceled or timed out before all functions finished executing, it will
+// send a cancel sign to all uncompleted functions, and return a context canceled error.
+func ParallelOfWithContext[T any](
+	ctx context.Context,
+	concurrency int,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	return parallelOf(ctx, concurrency, funcs...)
+}
+
+// parallelOf runs the typed functions asynchronously with the specified concurrency.
+func parallelOf[T any](
+	parent context.Context,
+	concurrency int,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	validateTypedFuncs(funcs...)
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	return runTasks(paralleler, len(funcs), typedFnTask(funcs))
+}
+
+// ParallelCompletedOf runs the typed functions asynchronously with the specified concurrency
+// limitation until all of the functions are finished. It's the generic version of
+// ParallelCompleted, it returns the results of the functions by the functions order, and the
+// execution errors of the functions that returned an error or panicked.
+//
+// The number of concurrency must be greater than or equal to 0, and it means no concurrency
+// limitation if the number is 0.
+func ParallelCompletedOf[T any](
+	concurrency int,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	return parallelCompletedOf(context.Background(), concurrency, funcs...)
+}
+
+// ParallelCompletedOfWithContext runs the typed functions asynchronously with the specified
+// concurrency limitation and the context until all of the functions are finished. It returns the
+// results of the functions by the functions order, and the execution errors of the functions that
+// returned an error or panicked.
+func ParallelCompletedOfWithContext[T any](
+	ctx context.Context,
+	concurrency int,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	return parallelCompletedOf(ctx, concurrency, funcs...)
+}
+
+// parallelCompletedOf runs the typed functions asynchronously with the specified concurrency until
+// all of the functions are finished.
+func parallelCompletedOf[T any](
+	parent context.Context,
+	concurrency int,
+	funcs ...func(context.Context) (T, error),
+) ([]T, error) {
+	validateTypedFuncs(funcs...)
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	return runTasksCompleted(paralleler, len(funcs), typedFnTask(funcs))
+}
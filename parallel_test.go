@@ -352,3 +352,131 @@ func ExampleParallelCompleted() {
 	// [[1] [expected error] [3]]
 	// function 1 error: expected error
 }
+
+func TestParallelOfWithConcurrencyLimit(t *testing.T) {
+	a := assert.New(t)
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return n, nil
+		})
+	}
+
+	start := time.Now()
+	out, err := async.ParallelOf(2, funcs...)
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(out, []int{0, 1, 2, 3, 4})
+	a.GteNow(dur, 150*time.Millisecond)
+	a.LtNow(dur, 200*time.Millisecond)
+}
+
+func TestParallelOfWithFailedTask(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("n = 2")
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			if n == 2 {
+				return 0, expectedErr
+			}
+			return n + 1, nil
+		})
+	}
+
+	out, err := async.ParallelOf(1, funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: n = 2")
+	a.EqualNow(out, []int{1, 2, 0, 0, 0})
+}
+
+func TestParallelOfWithTimedOutContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer canFunc()
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return n + 1, nil
+		})
+	}
+
+	out, err := async.ParallelOfWithContext(ctx, 1, funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.EqualNow(out, []int{1, 0, 0, 0, 0})
+}
+
+func TestParallelOfWithInvalidConcurrency(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.ParallelOf[int](-1)
+	}, async.ErrInvalidConcurrency)
+}
+
+func ExampleParallelOf() {
+	out, err := async.ParallelOf(2, func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	}, func(ctx context.Context) (int, error) {
+		return 2, nil
+	}, func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [1 2 3]
+	// <nil>
+}
+
+func TestParallelCompletedOfWithFailedTask(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("n = 2")
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			if n == 2 {
+				return 0, expectedErr
+			}
+			return n + 1, nil
+		})
+	}
+
+	out, err := async.ParallelCompletedOf(2, funcs...)
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: n = 2")
+	a.EqualNow(out, []int{1, 2, 0, 4, 5})
+}
+
+func TestParallelCompletedOfWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.ParallelCompletedOfWithContext(
+		context.Background(),
+		0,
+		func(ctx context.Context) (string, error) {
+			return "hello", nil
+		},
+		func(ctx context.Context) (string, error) {
+			return "world", nil
+		},
+	)
+	a.NilNow(err)
+	a.EqualNow(out, []string{"hello", "world"})
+}
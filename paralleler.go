@@ -3,7 +3,6 @@ package async
 import (
 	"context"
 	"sync"
-	"sync/atomic"
 )
 
 // builtinPool is the Parallelers pool for built-in functions.
@@ -19,6 +18,8 @@ type Paralleler struct {
 	concurrency int
 	ctx         context.Context
 	locker      sync.Mutex
+	sem         *Semaphore
+	limiter     *RateLimiter
 	tasks       []AsyncFn
 }
 
@@ -40,6 +41,24 @@ func (p *Paralleler) WithContext(ctx context.Context) *Paralleler {
 	return p
 }
 
+// WithSemaphore sets the semaphore that limits the number of the running tasks with all other
+// callers that share the semaphore, every task takes a weight of 1 from the semaphore while it's
+// running. It overrides the semaphore that the paralleler's context carries.
+func (p *Paralleler) WithSemaphore(sem *Semaphore) *Paralleler {
+	p.sem = sem
+
+	return p
+}
+
+// WithRateLimit sets the rate limitation of the tasks, the tasks will be started no more than the
+// rate per second with the burst. It'll panic if the rate is less than or equal to 0, or the burst
+// is less than 1.
+func (p *Paralleler) WithRateLimit(rate float64, burst int) *Paralleler {
+	p.limiter = NewRateLimiter(rate, burst)
+
+	return p
+}
+
 // Add adds the functions into the pending tasks list.
 func (p *Paralleler) Add(funcs ...AsyncFn) *Paralleler {
 	validateAsyncFuncs(funcs...)
@@ -66,8 +85,76 @@ func (p *Paralleler) Clear() *Paralleler {
 // the results of the tasks.
 func (p *Paralleler) Run() ([][]any, error) {
 	tasks := p.getTasks()
-	out := make([][]any, len(tasks))
-	if len(tasks) == 0 {
+	return runTasks(p, len(tasks), asyncFnTask(tasks))
+}
+
+// RunCompleted runs the tasks in the paralleler's pending list until all functions are finished,
+// it'll clear the pending list and return the results of the tasks.
+func (p *Paralleler) RunCompleted() ([][]any, error) {
+	tasks := p.getTasks()
+	return runTasksCompleted(p, len(tasks), asyncFnTask(tasks))
+}
+
+// getConcurrencyChan creates and returns a concurrency controlling channel by the specific number
+// of the concurrency limitation.
+func (p *Paralleler) getConcurrencyChan() chan empty {
+	var conch chan empty
+
+	if p.concurrency > 0 {
+		conch = make(chan empty, p.concurrency)
+	}
+
+	return conch
+}
+
+// getSemaphore returns the paralleler's semaphore, or the semaphore that the context carries if the
+// paralleler has no semaphore.
+func (p *Paralleler) getSemaphore(ctx context.Context) *Semaphore {
+	if p.sem != nil {
+		return p.sem
+	}
+
+	return getContextSemaphore(ctx)
+}
+
+// getTasks returns the tasks from the pending list, and clear the pending list to receiving new
+// tasks.
+func (p *Paralleler) getTasks() []AsyncFn {
+	p.locker.Lock()
+
+	tasks := p.tasks
+	p.tasks = nil
+
+	p.locker.Unlock()
+
+	return tasks
+}
+
+// taskFn is the function to run the n-th task of a paralleler's run with the specified context,
+// and returns the result and the error of the task.
+type taskFn[T any] func(ctx context.Context, n int) (T, error)
+
+// asyncFnTask returns a taskFn that invokes the AsyncFn in the list by the index.
+func asyncFnTask(funcs []AsyncFn) taskFn[[]any] {
+	return func(ctx context.Context, n int) ([]any, error) {
+		return invokeAsyncFn(funcs[n], ctx, nil)
+	}
+}
+
+// typedFnTask returns a taskFn that calls the typed function in the list by the index without the
+// reflection.
+func typedFnTask[T any](funcs []func(context.Context) (T, error)) taskFn[T] {
+	return func(ctx context.Context, n int) (T, error) {
+		return invokeTypedFn(funcs[n], ctx)
+	}
+}
+
+// runTasks runs the number of tasks with the paralleler's context and concurrency limitation. It
+// will send a cancel signal to the context and return an execution error immediately if any task
+// returns an error or panics.
+func runTasks[T any](p *Paralleler, num int, fn taskFn[T]) ([]T, error) {
+	out := make([]T, num)
+	if num == 0 {
 		return out, nil
 	}
 
@@ -75,12 +162,21 @@ func (p *Paralleler) Run() ([][]any, error) {
 	ctx, canFunc := context.WithCancel(parent)
 	defer canFunc()
 
-	ch := make(chan executeResult, len(tasks))
+	ch := make(chan executeResult[T], num)
 
-	go p.runTasks(ctx, ch, tasks, true)
+	go scheduleTasks(
+		ctx,
+		p.getConcurrencyChan(),
+		p.getSemaphore(parent),
+		p.limiter,
+		ch,
+		num,
+		fn,
+		true,
+	)
 
 	finished := 0
-	for finished < len(tasks) {
+	for finished < num {
 		select {
 		case <-parent.Done():
 			return out, ErrContextCanceled
@@ -99,104 +195,127 @@ func (p *Paralleler) Run() ([][]any, error) {
 	return out, nil
 }
 
-// RunCompleted runs the tasks in the paralleler's pending list until all functions are finished,
-// it'll clear the pending list and return the results of the tasks.
-func (p *Paralleler) RunCompleted() ([][]any, error) {
-	tasks := p.getTasks()
-	out := make([][]any, len(tasks))
-	if len(tasks) == 0 {
+// runTasksCompleted runs the number of tasks with the paralleler's context and concurrency
+// limitation until all tasks are finished. It returns the execution errors of the failed tasks.
+func runTasksCompleted[T any](p *Paralleler, num int, fn taskFn[T]) ([]T, error) {
+	out := make([]T, num)
+	if num == 0 {
 		return out, nil
 	}
 
-	errs := make([]error, len(tasks))
-	errNum := atomic.Int32{}
+	errs := make([]error, num)
+	errNum := 0
 	parent := getContext(p.ctx)
 	ctx, canFunc := context.WithCancel(parent)
 	defer canFunc()
 
-	ch := make(chan executeResult, len(tasks))
+	ch := make(chan executeResult[T], num)
 
-	go p.runTasks(ctx, ch, tasks, false)
+	go scheduleTasks(
+		ctx,
+		p.getConcurrencyChan(),
+		p.getSemaphore(parent),
+		p.limiter,
+		ch,
+		num,
+		fn,
+		false,
+	)
 
-	for finished := 0; finished < len(tasks); finished++ {
+	for finished := 0; finished < num; finished++ {
 		ret := <-ch
 		out[ret.Index] = ret.Out
 		if ret.Error != nil {
 			errs[ret.Index] = ret.Error
-			errNum.Add(1)
+			errNum++
 		}
 	}
 
-	if errNum.Load() == 0 {
+	if errNum == 0 {
 		return out, nil
 	}
 
-	return out, convertErrorListToExecutionErrors(errs, int(errNum.Load()))
-}
-
-// getConcurrencyChan creates and returns a concurrency controlling channel by the specific number
-// of the concurrency limitation.
-func (p *Paralleler) getConcurrencyChan() chan empty {
-	var conch chan empty
-
-	if p.concurrency > 0 {
-		conch = make(chan empty, p.concurrency)
-	}
-
-	return conch
-}
-
-// getTasks returns the tasks from the pending list, and clear the pending list to receiving new
-// tasks.
-func (p *Paralleler) getTasks() []AsyncFn {
-	p.locker.Lock()
-
-	tasks := p.tasks
-	p.tasks = nil
-
-	p.locker.Unlock()
-
-	return tasks
+	return out, convertErrorListToExecutionErrors(errs, errNum)
 }
 
-// runTasks runs the tasks with the concurrency limitation.
-func (p *Paralleler) runTasks(
+// scheduleTasks runs the tasks with the concurrency limitation, the semaphore, and the rate
+// limiter. If the context is done while waiting for the semaphore or the rate limiter, the tasks
+// that have not been started will not run, and they'll be failed with the context canceled error
+// if exitWhenDone is false.
+func scheduleTasks[T any](
 	ctx context.Context,
-	resCh chan executeResult,
-	tasks []AsyncFn,
+	conch chan empty,
+	sem *Semaphore,
+	limiter *RateLimiter,
+	resCh chan executeResult[T],
+	num int,
+	fn taskFn[T],
 	exitWhenDone bool,
 ) {
-	conch := p.getConcurrencyChan()
-
-	for i := 0; i < len(tasks); i++ {
+	for i := 0; i < num; i++ {
 		if conch != nil {
 			conch <- empty{}
 		}
 
-		go p.runTask(ctx, i, tasks[i], conch, resCh, exitWhenDone)
+		if err := waitTask(ctx, sem, limiter); err != nil {
+			if conch != nil {
+				<-conch
+			}
+			if !exitWhenDone {
+				for ; i < num; i++ {
+					resCh <- executeResult[T]{
+						Index: i,
+						Error: err,
+					}
+				}
+			}
+			return
+		}
+
+		go runTask(ctx, i, fn, conch, sem, resCh, exitWhenDone)
+	}
+}
+
+// waitTask waits for the rate limiter and acquires the semaphore before running a task.
+func waitTask(ctx context.Context, sem *Semaphore, limiter *RateLimiter) error {
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
 	}
+	if sem != nil {
+		return sem.Acquire(ctx, 1)
+	}
+
+	return nil
 }
 
-// runTask runs the task function, and
-func (p *Paralleler) runTask(
+// runTask runs the n-th task, and sends the result to the channel.
+func runTask[T any](
 	ctx context.Context,
 	n int,
-	fn AsyncFn,
+	fn taskFn[T],
 	conch chan empty,
-	ch chan executeResult,
+	sem *Semaphore,
+	ch chan executeResult[T],
 	exitWhenDone bool,
 ) {
 	childCtx, childCanFunc := context.WithCancel(ctx)
 	defer childCanFunc()
 
-	ret, err := invokeAsyncFn(fn, childCtx, nil)
+	// the task holds a weight of the semaphore, the nested calls with its context should not acquire
+	// the semaphore again, otherwise they may be blocked forever by the waiting tasks.
+	ret, err := fn(withoutSemaphore(childCtx, sem), n)
 
+	if sem != nil {
+		sem.Release(1)
+	}
 	if conch != nil {
 		<-conch
 	}
 
 	if !exitWhenDone {
-		ch <- executeResult{
+		ch <- executeResult[T]{
 			Index: n,
 			Error: err,
 			Out:   ret,
@@ -206,7 +325,7 @@ func (p *Paralleler) runTask(
 		case <-ctx.Done():
 			return
 		default:
-			ch <- executeResult{
+			ch <- executeResult[T]{
 				Index: n,
 				Error: err,
 				Out:   ret,
@@ -206,6 +206,90 @@ func TestParallelerRunCompletedWithFailure(t *testing.T) {
 	a.EqualNow(cnt.Load(), 5)
 }
 
+func TestParallelerWithSemaphore(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(2)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	newParalleler := func() *async.Paralleler {
+		p := new(async.Paralleler).WithSemaphore(sem)
+		for i := 0; i < 3; i++ {
+			p.Add(func() {
+				cur := running.Add(1)
+				defer running.Add(-1)
+				for {
+					old := maxRunning.Load()
+					if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			})
+		}
+		return p
+	}
+
+	p1 := newParalleler()
+	p2 := newParalleler()
+	done := make(chan error)
+	go func() {
+		_, err := p1.Run()
+		done <- err
+	}()
+	_, err := p2.RunCompleted()
+	a.NilNow(err)
+	a.NilNow(<-done)
+
+	a.EqualNow(maxRunning.Load(), 2)
+	a.EqualNow(sem.Available(), 2)
+}
+
+func TestParallelerWithRateLimit(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	p := new(async.Paralleler).WithRateLimit(50, 1)
+	for i := 0; i < 3; i++ {
+		p.Add(func() {
+			cnt.Add(1)
+		})
+	}
+
+	start := time.Now()
+	_, err := p.Run()
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(cnt.Load(), 3)
+	a.GteNow(dur, 35*time.Millisecond)
+	a.LtNow(dur, 60*time.Millisecond)
+
+	a.PanicOfNow(func() {
+		new(async.Paralleler).WithRateLimit(0, 1)
+	}, async.ErrInvalidRateLimit)
+}
+
+func TestParallelerWithRateLimitAndContext(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer canFunc()
+
+	p := new(async.Paralleler).WithContext(ctx).WithRateLimit(10, 1)
+	for i := 0; i < 3; i++ {
+		p.Add(func() {
+			cnt.Add(1)
+		})
+	}
+
+	out, err := p.RunCompleted()
+	a.NotNilNow(err)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.EqualNow(len(out), 3)
+	a.EqualNow(cnt.Load(), 1)
+}
+
 func ExampleParalleler() {
 	p := new(async.Paralleler)
 
@@ -0,0 +1,260 @@
+package async
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+const (
+	defaultPriorityQueueConcurrency int = 1
+	defaultPriorityQueueAgingStep   int = 1
+)
+
+type PriorityQueueOptions struct {
+	// Concurrency is the number of the tasks that can be run at the same time, the default is 1.
+	Concurrency int
+	// AgingInterval is the interval to increase the priority of the pending tasks to prevent the low
+	// priority tasks from starvation, the priority of a pending task increases by AgingStep for every
+	// AgingInterval it waits. The aging is disabled if it's less than or equal to 0.
+	AgingInterval time.Duration
+	// AgingStep is the priority to increase for every AgingInterval, the default is 1.
+	AgingStep int
+}
+
+// PriorityQueue is a long-lived tasks queue that runs the functions by their priorities with the
+// specific concurrency. The pending task with the higher priority will be run before the pending
+// tasks with the lower priorities, and the tasks with the same priority will be run in the order
+// they were pushed. The running tasks will not be affected by the tasks that are pushed later.
+//
+// Like Paralleler, the functions can be any function, and the panic of the function will be caught
+// and returned as an error.
+//
+//	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+//	  Concurrency:   2,
+//	  AgingInterval: time.Second,
+//	})
+//	q.Push(func(ctx context.Context) error {
+//	  // Do something
+//	}, 10)
+//	out, err := q.Push(func(ctx context.Context) (int, error) {
+//	  // Do something
+//	}, 1).Await(context.Background())
+type PriorityQueue struct {
+	queue *Queue[priorityTask, []any]
+}
+
+// priorityTask is the function and the priority of a task in the priority queue.
+type priorityTask struct {
+	fn       AsyncFn
+	priority int
+}
+
+// NewPriorityQueue creates a priority queue with the options. It'll panic if the concurrency is
+// less than 0.
+func NewPriorityQueue(opts ...PriorityQueueOptions) *PriorityQueue {
+	return newPriorityQueue(context.Background(), opts...)
+}
+
+// NewPriorityQueueWithContext creates a priority queue with the context and the options, and the
+// context will pass to the functions. The queue will be closed when the context is done, and the
+// running tasks will receive the cancel signal by the context.
+func NewPriorityQueueWithContext(
+	ctx context.Context,
+	opts ...PriorityQueueOptions,
+) *PriorityQueue {
+	return newPriorityQueue(ctx, opts...)
+}
+
+// newPriorityQueue creates a priority queue that runs the pending tasks from a priority store.
+func newPriorityQueue(ctx context.Context, opts ...PriorityQueueOptions) *PriorityQueue {
+	opt := getPriorityQueueOption(opts...)
+
+	store := &priorityStore{
+		start: time.Now(),
+	}
+	if opt.AgingInterval > 0 {
+		store.agingRate = float64(opt.AgingStep) / float64(opt.AgingInterval)
+	}
+
+	queue := newQueue[priorityTask, []any](
+		ctx,
+		func(ctx context.Context, task priorityTask) ([]any, error) {
+			return invokeAsyncFn(task.fn, ctx, nil)
+		},
+		QueueOptions{
+			Concurrency: opt.Concurrency,
+		},
+		store,
+	)
+
+	return &PriorityQueue{
+		queue: queue,
+	}
+}
+
+// getPriorityQueueOption gets the priority queue option by the customize option or the default
+// values.
+func getPriorityQueueOption(opts ...PriorityQueueOptions) PriorityQueueOptions {
+	opt := PriorityQueueOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.Concurrency < 0 {
+		panic(ErrInvalidConcurrency)
+	} else if opt.Concurrency == 0 {
+		opt.Concurrency = defaultPriorityQueueConcurrency
+	}
+	if opt.AgingStep <= 0 {
+		opt.AgingStep = defaultPriorityQueueAgingStep
+	}
+
+	return opt
+}
+
+// Push pushes the function with the priority into the queue, and returns a future to get the
+// result of the function. The callbacks will be invoked with the result after the task finished.
+// If the queue has been closed, the future will be failed with ErrQueueClosed. It'll panic if the
+// function is nil or not a function.
+func (q *PriorityQueue) Push(
+	fn AsyncFn,
+	priority int,
+	callbacks ...func([]any, error),
+) *Future[[]any] {
+	validateAsyncFuncs(fn)
+
+	return q.queue.Push(priorityTask{
+		fn:       fn,
+		priority: priority,
+	}, callbacks...)
+}
+
+// Pause pauses the queue, the queue will not start any new task until it's resumed. The running
+// tasks will not be affected.
+func (q *PriorityQueue) Pause() {
+	q.queue.Pause()
+}
+
+// Resume resumes the paused queue, and starts the pending tasks by their priorities if there are
+// available workers.
+func (q *PriorityQueue) Resume() {
+	q.queue.Resume()
+}
+
+// IsPaused returns a boolean value to indicate whether the queue is paused or not.
+func (q *PriorityQueue) IsPaused() bool {
+	return q.queue.IsPaused()
+}
+
+// Len returns the number of the pending tasks in the queue.
+func (q *PriorityQueue) Len() int {
+	return q.queue.Len()
+}
+
+// Running returns the number of the running tasks.
+func (q *PriorityQueue) Running() int {
+	return q.queue.Running()
+}
+
+// Idle returns a boolean value to indicate whether the queue has no pending and running task.
+func (q *PriorityQueue) Idle() bool {
+	return q.queue.Idle()
+}
+
+// Close closes the queue gracefully, the queue will not receive any new task, and it waits for all
+// of the pushed tasks to finish. If the context is done (canceled or timeout) before all tasks
+// finished, it will send a cancel signal to the running tasks by context, fail the pending tasks
+// with ErrQueueClosed, and return a context canceled error.
+func (q *PriorityQueue) Close(ctx context.Context) error {
+	return q.queue.Close(ctx)
+}
+
+// priorityStore is the store that pops the task with the highest effective priority, the effective
+// priority of a task is its priority plus the aging priority by the time it waited.
+type priorityStore struct {
+	// start is the time that the store was created, the pushing time of the tasks are relative to
+	// it.
+	start time.Time
+	// agingRate is the priority to increase for every nanosecond that a task waited.
+	agingRate float64
+	// seq is the sequence number of the next pushed task.
+	seq uint64
+	// items is the heap of the pending tasks.
+	items priorityItems
+}
+
+// priorityItem is a pending task in the priority store.
+type priorityItem struct {
+	task *queueTask[priorityTask, []any]
+	// key is the priority of the task without the aging priority that increases after it was pushed.
+	// Because all of the pending tasks are aging at the same rate, the order of the keys is the same
+	// as the order of the effective priorities at any time.
+	key float64
+	// seq is the sequence number to keep the order of the tasks with the same key.
+	seq uint64
+}
+
+// push adds the task into the store.
+func (s *priorityStore) push(task *queueTask[priorityTask, []any]) {
+	waited := float64(time.Since(s.start))
+
+	heap.Push(&s.items, &priorityItem{
+		task: task,
+		key:  float64(task.data.priority) - s.agingRate*waited,
+		seq:  s.seq,
+	})
+	s.seq++
+}
+
+// pop removes and returns the task with the highest effective priority.
+func (s *priorityStore) pop() *queueTask[priorityTask, []any] {
+	return heap.Pop(&s.items).(*priorityItem).task
+}
+
+// len returns the number of the tasks in the store.
+func (s *priorityStore) len() int {
+	return len(s.items)
+}
+
+// clear removes and returns all of the tasks in the store.
+func (s *priorityStore) clear() []*queueTask[priorityTask, []any] {
+	tasks := make([]*queueTask[priorityTask, []any], 0, len(s.items))
+	for s.len() > 0 {
+		tasks = append(tasks, s.pop())
+	}
+
+	return tasks
+}
+
+// priorityItems is a max-heap of the priority items that implements heap.Interface.
+type priorityItems []*priorityItem
+
+func (items priorityItems) Len() int {
+	return len(items)
+}
+
+func (items priorityItems) Less(i, j int) bool {
+	if items[i].key != items[j].key {
+		return items[i].key > items[j].key
+	}
+	return items[i].seq < items[j].seq
+}
+
+func (items priorityItems) Swap(i, j int) {
+	items[i], items[j] = items[j], items[i]
+}
+
+func (items *priorityItems) Push(x any) {
+	*items = append(*items, x.(*priorityItem))
+}
+
+func (items *priorityItems) Pop() any {
+	old := *items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*items = old[:n-1]
+
+	return item
+}
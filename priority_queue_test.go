@@ -0,0 +1,254 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	order := make([]int, 0, 5)
+
+	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+		Concurrency: 1,
+	})
+	q.Pause()
+
+	futures := make([]*async.Future[[]any], 0, 5)
+	for _, priority := range []int{1, 5, 3, 5, 0} {
+		n := len(futures)
+		futures = append(futures, q.Push(func(ctx context.Context) (int, error) {
+			locker.Lock()
+			defer locker.Unlock()
+			order = append(order, n)
+			return n, nil
+		}, priority))
+	}
+	a.EqualNow(q.Len(), 5)
+
+	q.Resume()
+	for i, f := range futures {
+		out, err := f.Await(context.Background())
+		a.NilNow(err)
+		a.EqualNow(out, []any{i, nil})
+	}
+	// the tasks with the same priority are run in the order they were pushed.
+	a.EqualNow(order, []int{1, 3, 2, 0, 4})
+
+	a.NilNow(q.Close(context.Background()))
+	a.TrueNow(q.Idle())
+}
+
+func TestPriorityQueuePreemptPendingTasks(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	order := make([]string, 0, 3)
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+		Concurrency: 1,
+	})
+	push := func(name string, priority int) {
+		q.Push(func() {
+			locker.Lock()
+			defer locker.Unlock()
+			order = append(order, name)
+		}, priority)
+	}
+
+	q.Push(func() {
+		close(started)
+		<-release
+	}, 0)
+	<-started
+	push("low", 1)
+	// the high priority task will not preempt the running task.
+	push("high", 10)
+	a.EqualNow(q.Running(), 1)
+	a.EqualNow(q.Len(), 2)
+
+	close(release)
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(order, []string{"high", "low"})
+}
+
+func TestPriorityQueueWithAging(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	order := make([]int, 0, 3)
+
+	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+		Concurrency:   1,
+		AgingInterval: 10 * time.Millisecond,
+	})
+	q.Pause()
+	push := func(priority int) {
+		q.Push(func() {
+			locker.Lock()
+			defer locker.Unlock()
+			order = append(order, priority)
+		}, priority)
+	}
+
+	push(0)
+	time.Sleep(50 * time.Millisecond)
+	// the first task has waited about 5 intervals, so its priority is higher than 3 now.
+	push(3)
+	push(10)
+
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(order, []int{10, 0, 3})
+}
+
+func TestPriorityQueueWithAgingStep(t *testing.T) {
+	a := assert.New(t)
+	locker := sync.Mutex{}
+	order := make([]int, 0, 2)
+
+	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+		Concurrency:   1,
+		AgingInterval: 10 * time.Millisecond,
+		AgingStep:     10,
+	})
+	q.Pause()
+	push := func(priority int) {
+		q.Push(func() {
+			locker.Lock()
+			defer locker.Unlock()
+			order = append(order, priority)
+		}, priority)
+	}
+
+	push(0)
+	time.Sleep(30 * time.Millisecond)
+	push(20)
+
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(order, []int{0, 20})
+}
+
+func TestPriorityQueueDefaultConcurrency(t *testing.T) {
+	a := assert.New(t)
+	order := make([]int, 0, 3)
+	release := make(chan struct{})
+
+	q := async.NewPriorityQueue()
+	q.Push(func() {
+		<-release
+		order = append(order, 0)
+	}, 0)
+	a.EqualNow(q.Running(), 1)
+
+	// the pending tasks are run by their priorities one at a time.
+	q.Push(func() {
+		order = append(order, 1)
+	}, 1)
+	q.Push(func() {
+		order = append(order, 2)
+	}, 2)
+	a.EqualNow(q.Len(), 2)
+
+	close(release)
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(order, []int{0, 2, 1})
+}
+
+func TestPriorityQueueWithInvalidOptions(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewPriorityQueue(async.PriorityQueueOptions{
+			Concurrency: -1,
+		})
+	}, async.ErrInvalidConcurrency)
+
+	q := async.NewPriorityQueue()
+	a.PanicOfNow(func() {
+		q.Push(nil, 0)
+	}, async.ErrNotFunction)
+	a.PanicOfNow(func() {
+		q.Push(1, 0)
+	}, async.ErrNotFunction)
+	a.NilNow(q.Close(context.Background()))
+}
+
+func TestPriorityQueueWithError(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	results := make(chan string, 2)
+
+	q := async.NewPriorityQueue()
+	callback := func(out []any, err error) {
+		results <- fmt.Sprintf("%v:%v", out, err)
+	}
+
+	_, err := q.Push(func() error {
+		return expectedErr
+	}, 0, callback).Await(context.Background())
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(<-results, "[expected error]:expected error")
+
+	_, err = q.Push(func() {
+		panic("expected panic")
+	}, 0, callback).Await(context.Background())
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "expected panic")
+	a.EqualNow(<-results, "[]:expected panic")
+
+	a.NilNow(q.Close(context.Background()))
+}
+
+func TestPriorityQueueWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithCancel(context.Background())
+	q := async.NewPriorityQueueWithContext(ctx, async.PriorityQueueOptions{
+		Concurrency: 1,
+	})
+	f1 := q.Push(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, 0)
+	f2 := q.Push(func() {}, 0)
+
+	canFunc()
+	_, err := f1.Await(context.Background())
+	a.IsErrorNow(err, context.Canceled)
+	_, err = f2.Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+	a.NilNow(q.Close(context.Background()))
+
+	_, err = q.Push(func() {}, 0).Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+}
+
+func ExamplePriorityQueue() {
+	q := async.NewPriorityQueue(async.PriorityQueueOptions{
+		Concurrency: 1,
+	})
+	q.Pause()
+
+	for i, priority := range []int{1, 3, 2} {
+		n := i
+		q.Push(func() {
+			fmt.Println(n)
+		}, priority)
+	}
+	q.Resume()
+
+	fmt.Println(q.Close(context.Background()))
+	// Output:
+	// 1
+	// 2
+	// 0
+	// <nil>
+}
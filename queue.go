@@ -0,0 +1,419 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultQueueConcurrency int = 1
+
+type QueueOptions struct {
+	// Concurrency is the number of the tasks that can be run at the same time, the default is 1.
+	Concurrency int
+	// OnSaturated is the function that is invoked when the number of the running tasks reaches the
+	// concurrency limitation, and the further tasks will be queued.
+	OnSaturated func()
+	// OnEmpty is the function that is invoked when the last task in the queue was given to a worker.
+	OnEmpty func()
+	// OnDrain is the function that is invoked when the last task was finished, and there is no
+	// pending task in the queue.
+	OnDrain func()
+}
+
+// Queue is a long-lived tasks queue that runs the worker function with the pushed data with the
+// specific concurrency, it's similar to the queue of Node.js async library. The data can be pushed
+// into the queue from any goroutine at any time, and every pushed data gets a future to get the
+// result of the worker function. Unlike Paralleler, the tasks that are pushed while the queue is
+// running will be run as soon as a worker is available.
+//
+//	q := async.NewQueue(func(ctx context.Context, url string) (int, error) {
+//	  return Download(ctx, url)
+//	}, async.QueueOptions{
+//	  Concurrency: 2,
+//	})
+//	f := q.Push("https://example.com")
+//	size, err := f.Await(context.Background())
+//	// Stop receiving the new tasks and wait for the pushed tasks to finish.
+//	q.Close(context.Background())
+type Queue[T, R any] struct {
+	locker sync.Mutex
+	opts   QueueOptions
+	// parent is the context that the queue was created with, it passes to the functions that are
+	// chained on the tasks' futures.
+	parent   context.Context
+	ctx      context.Context
+	canFunc  context.CancelFunc
+	workerFn func(context.Context, T) (R, error)
+	// tasks is the store of the pending tasks in the queue.
+	tasks queueStore[T, R]
+	// running is the number of the running tasks.
+	running int
+	// isPaused indicates the queue is paused and no more tasks will be started.
+	isPaused bool
+	// isClosed indicates the queue is closed and no more tasks can be pushed.
+	isClosed bool
+	// closed is the channel that will be closed after the queue is closed and all tasks are
+	// finished.
+	closed chan struct{}
+}
+
+// queueStore is the store of the pending tasks in the queue, it decides the order of the tasks to
+// run.
+type queueStore[T, R any] interface {
+	// push adds the task into the store.
+	push(task *queueTask[T, R])
+	// pop removes and returns the next task to run from the store.
+	pop() *queueTask[T, R]
+	// len returns the number of the tasks in the store.
+	len() int
+	// clear removes and returns all of the tasks in the store.
+	clear() []*queueTask[T, R]
+}
+
+// fifoStore is the store that runs the tasks in the order they were pushed.
+type fifoStore[T, R any] struct {
+	tasks []*queueTask[T, R]
+}
+
+// push adds the task to the end of the store.
+func (s *fifoStore[T, R]) push(task *queueTask[T, R]) {
+	s.tasks = append(s.tasks, task)
+}
+
+// pop removes and returns the first task in the store.
+func (s *fifoStore[T, R]) pop() *queueTask[T, R] {
+	task := s.tasks[0]
+	s.tasks[0] = nil
+	s.tasks = s.tasks[1:]
+
+	return task
+}
+
+// len returns the number of the tasks in the store.
+func (s *fifoStore[T, R]) len() int {
+	return len(s.tasks)
+}
+
+// clear removes and returns all of the tasks in the store.
+func (s *fifoStore[T, R]) clear() []*queueTask[T, R] {
+	tasks := s.tasks
+	s.tasks = nil
+
+	return tasks
+}
+
+// queueTask is a task in the queue that contains the data to pass to the worker function.
+type queueTask[T, R any] struct {
+	// data is the data to pass to the worker function.
+	data T
+	// future is the future to store the result of the task.
+	future *Future[R]
+	// callbacks are the functions that will be invoked after the task is finished.
+	callbacks []func(R, error)
+}
+
+// NewQueue creates a queue to run the worker function with the pushed data. It'll panic if the
+// worker function is nil.
+func NewQueue[T, R any](
+	fn func(context.Context, T) (R, error),
+	opts ...QueueOptions,
+) *Queue[T, R] {
+	return newQueue[T, R](context.Background(), fn, getQueueOption(opts...), &fifoStore[T, R]{})
+}
+
+// NewQueueWithContext creates a queue to run the worker function with the pushed data, and the
+// context will pass to the worker function. The queue will be closed when the context is done, and
+// the running tasks will receive the cancel signal by the context.
+func NewQueueWithContext[T, R any](
+	ctx context.Context,
+	fn func(context.Context, T) (R, error),
+	opts ...QueueOptions,
+) *Queue[T, R] {
+	return newQueue[T, R](ctx, fn, getQueueOption(opts...), &fifoStore[T, R]{})
+}
+
+// newQueue creates a queue with the context, the options, and the store of the pending tasks.
+func newQueue[T, R any](
+	parent context.Context,
+	fn func(context.Context, T) (R, error),
+	opt QueueOptions,
+	store queueStore[T, R],
+) *Queue[T, R] {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	q := &Queue[T, R]{
+		opts:     opt,
+		workerFn: fn,
+		tasks:    store,
+		closed:   make(chan struct{}),
+	}
+	q.parent = getContext(parent)
+	q.ctx, q.canFunc = context.WithCancel(q.parent)
+
+	go func() {
+		select {
+		case <-q.ctx.Done():
+			q.abort()
+		case <-q.closed:
+		}
+	}()
+
+	return q
+}
+
+// getQueueOption gets the queue option by the customize option or the default values.
+func getQueueOption(opts ...QueueOptions) QueueOptions {
+	opt := QueueOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultQueueConcurrency
+	}
+
+	return opt
+}
+
+// Push pushes the data into the queue, and returns a future to get the result of the worker
+// function with the data. The callbacks will be invoked with the result after the task finished.
+// If the queue has been closed, the future will be failed with ErrQueueClosed.
+func (q *Queue[T, R]) Push(data T, callbacks ...func(R, error)) *Future[R] {
+	return q.push([]T{data}, callbacks)[0]
+}
+
+// PushMany pushes the data list into the queue in order, and returns the futures to get the
+// results of the worker function with the data. The callbacks will be invoked with the result
+// after each task finished. If the queue has been closed, the futures will be failed with
+// ErrQueueClosed.
+func (q *Queue[T, R]) PushMany(data []T, callbacks ...func(R, error)) []*Future[R] {
+	return q.push(data, callbacks)
+}
+
+// push pushes the data list into the queue, and starts the tasks if there are available workers.
+func (q *Queue[T, R]) push(data []T, callbacks []func(R, error)) []*Future[R] {
+	futures := make([]*Future[R], 0, len(data))
+	tasks := make([]*queueTask[T, R], 0, len(data))
+	for _, v := range data {
+		task := &queueTask[T, R]{
+			data: v,
+			future: &Future[R]{
+				// uses the parent context to keep the chained functions working after the queue is
+				// closed.
+				ctx:  q.parent,
+				done: make(chan struct{}),
+			},
+			callbacks: callbacks,
+		}
+		tasks = append(tasks, task)
+		futures = append(futures, task.future)
+	}
+
+	q.locker.Lock()
+	if q.isClosed {
+		q.locker.Unlock()
+		var out R
+		for _, task := range tasks {
+			task.finish(out, ErrQueueClosed)
+		}
+		return futures
+	}
+	for _, task := range tasks {
+		q.tasks.push(task)
+	}
+	events := q.process()
+	q.locker.Unlock()
+
+	q.emit(events)
+
+	return futures
+}
+
+// Pause pauses the queue, the queue will not start any new task until it's resumed. The running
+// tasks will not be affected.
+func (q *Queue[T, R]) Pause() {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	q.isPaused = true
+}
+
+// Resume resumes the paused queue, and starts the pending tasks if there are available workers.
+func (q *Queue[T, R]) Resume() {
+	q.locker.Lock()
+	q.isPaused = false
+	events := q.process()
+	q.locker.Unlock()
+
+	q.emit(events)
+}
+
+// IsPaused returns a boolean value to indicate whether the queue is paused or not.
+func (q *Queue[T, R]) IsPaused() bool {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	return q.isPaused
+}
+
+// Len returns the number of the pending tasks in the queue.
+func (q *Queue[T, R]) Len() int {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	return q.tasks.len()
+}
+
+// Running returns the number of the running tasks.
+func (q *Queue[T, R]) Running() int {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	return q.running
+}
+
+// Idle returns a boolean value to indicate whether the queue has no pending and running task.
+func (q *Queue[T, R]) Idle() bool {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	return q.tasks.len() == 0 && q.running == 0
+}
+
+// Close closes the queue gracefully, the queue will not receive any new task, and it waits for all
+// of the pushed tasks to finish. The queue will be resumed if it's paused. If the context is done
+// (canceled or timeout) before all tasks finished, it will send a cancel signal to the running
+// tasks by context, fail the pending tasks with ErrQueueClosed, and return a context canceled
+// error.
+func (q *Queue[T, R]) Close(ctx context.Context) error {
+	ctx = getContext(ctx)
+
+	q.locker.Lock()
+	q.isClosed = true
+	q.isPaused = false
+	events := q.process()
+	q.checkClosed()
+	q.locker.Unlock()
+
+	q.emit(events)
+
+	select {
+	case <-q.closed:
+		q.canFunc()
+		return nil
+	case <-ctx.Done():
+		q.canFunc()
+		return ErrContextCanceled
+	}
+}
+
+// abort closes the queue after the queue's context is done, and fails the pending tasks with
+// ErrQueueClosed.
+func (q *Queue[T, R]) abort() {
+	q.locker.Lock()
+	q.isClosed = true
+	tasks := q.tasks.clear()
+	q.checkClosed()
+	q.locker.Unlock()
+
+	var out R
+	for _, task := range tasks {
+		task.finish(out, ErrQueueClosed)
+	}
+}
+
+// queueEvents is the events that are triggered by the state changes of the queue.
+type queueEvents struct {
+	isSaturated bool
+	isEmpty     bool
+	isDrain     bool
+}
+
+// process starts the pending tasks while there are available workers, it must be called with the
+// lock held. It returns the events to emit after the lock is released.
+func (q *Queue[T, R]) process() queueEvents {
+	events := queueEvents{}
+
+	// no more tasks will be started after the queue's context is done, and the pending tasks will be
+	// failed by abort.
+	for !q.isPaused && q.ctx.Err() == nil && q.tasks.len() > 0 && !q.isSaturated() {
+		task := q.tasks.pop()
+		q.running++
+
+		if q.tasks.len() == 0 {
+			events.isEmpty = true
+		}
+		if q.isSaturated() {
+			events.isSaturated = true
+		}
+
+		go q.run(task)
+	}
+
+	return events
+}
+
+// isSaturated returns a boolean value to indicate whether the number of the running tasks reaches
+// the concurrency limitation.
+func (q *Queue[T, R]) isSaturated() bool {
+	return q.running >= q.opts.Concurrency
+}
+
+// run runs the worker function with the task's data, and starts the next tasks after it finished.
+func (q *Queue[T, R]) run(task *queueTask[T, R]) {
+	out, err := invokeTypedFn(func(ctx context.Context) (R, error) {
+		return q.workerFn(ctx, task.data)
+	}, q.ctx)
+	task.finish(out, err)
+
+	q.locker.Lock()
+	q.running--
+	events := q.process()
+	if q.running == 0 && q.tasks.len() == 0 {
+		events.isDrain = true
+	}
+	q.checkClosed()
+	q.locker.Unlock()
+
+	q.emit(events)
+}
+
+// checkClosed closes the closed channel if the queue is closed and all tasks are finished, it must
+// be called with the lock held.
+func (q *Queue[T, R]) checkClosed() {
+	if !q.isClosed || q.running > 0 || q.tasks.len() > 0 {
+		return
+	}
+
+	select {
+	case <-q.closed:
+	default:
+		close(q.closed)
+	}
+}
+
+// emit invokes the event functions.
+func (q *Queue[T, R]) emit(events queueEvents) {
+	if events.isSaturated && q.opts.OnSaturated != nil {
+		q.opts.OnSaturated()
+	}
+	if events.isEmpty && q.opts.OnEmpty != nil {
+		q.opts.OnEmpty()
+	}
+	if events.isDrain && q.opts.OnDrain != nil {
+		q.opts.OnDrain()
+	}
+}
+
+// finish sets the result of the task, and invokes the callbacks.
+func (t *queueTask[T, R]) finish(out R, err error) {
+	t.future.out = out
+	t.future.err = err
+	close(t.future.done)
+
+	for _, callback := range t.callbacks {
+		callback(out, err)
+	}
+}
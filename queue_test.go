@@ -0,0 +1,326 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestQueue(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return n * 2, nil
+	}, async.QueueOptions{
+		Concurrency: 2,
+	})
+
+	start := time.Now()
+	futures := q.PushMany([]int{1, 2, 3, 4})
+	a.EqualNow(q.Running(), 2)
+	a.EqualNow(q.Len(), 2)
+
+	for i, f := range futures {
+		out, err := f.Await(context.Background())
+		a.NilNow(err)
+		a.EqualNow(out, (i+1)*2)
+	}
+	dur := time.Since(start)
+	a.GteNow(dur, 40*time.Millisecond)
+	a.LtNow(dur, 60*time.Millisecond)
+	a.EqualNow(maxRunning.Load(), 2)
+
+	a.NilNow(q.Close(context.Background()))
+	a.TrueNow(q.Idle())
+}
+
+func TestQueueWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewQueue[int, int](nil)
+	}, async.ErrNotFunction)
+}
+
+func TestQueuePushFromGoroutines(t *testing.T) {
+	a := assert.New(t)
+	sum := atomic.Int32{}
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		sum.Add(int32(n))
+		return n, nil
+	}, async.QueueOptions{
+		Concurrency: 3,
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			q.Push(n)
+		}(i)
+	}
+	wg.Wait()
+
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(sum.Load(), 55)
+}
+
+func TestQueueDynamicPush(t *testing.T) {
+	a := assert.New(t)
+	var q *async.Queue[int, int]
+	results := make(chan int, 3)
+
+	q = async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		if n < 3 {
+			// push a new task while the queue is running.
+			q.Push(n + 1)
+		}
+		results <- n
+		return n, nil
+	})
+	q.Push(1)
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case n := <-results:
+			a.EqualNow(n, i)
+		case <-time.After(100 * time.Millisecond):
+			a.FailNow()
+		}
+	}
+	a.NilNow(q.Close(context.Background()))
+}
+
+func TestQueueWithCallbacks(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	locker := sync.Mutex{}
+	results := make([]string, 0, 3)
+
+	q := async.NewQueue(func(ctx context.Context, n int) (string, error) {
+		if n == 2 {
+			return "", expectedErr
+		}
+		if n == 3 {
+			panic("expected panic")
+		}
+		return fmt.Sprint(n), nil
+	})
+
+	callback := func(out string, err error) {
+		locker.Lock()
+		defer locker.Unlock()
+		results = append(results, fmt.Sprintf("%s:%v", out, err))
+	}
+	q.Push(1, callback)
+	q.PushMany([]int{2, 3}, callback)
+
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(results, []string{"1:<nil>", ":expected error", ":expected panic"})
+}
+
+func TestQueuePauseAndResume(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		cnt.Add(1)
+		return n, nil
+	})
+	q.Pause()
+	a.TrueNow(q.IsPaused())
+
+	f := q.Push(1)
+	time.Sleep(10 * time.Millisecond)
+	a.EqualNow(cnt.Load(), 0)
+	a.EqualNow(q.Len(), 1)
+
+	q.Resume()
+	a.NotTrueNow(q.IsPaused())
+	out, err := f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+	a.EqualNow(cnt.Load(), 1)
+
+	// close resumes the paused queue.
+	q.Pause()
+	f = q.Push(2)
+	a.NilNow(q.Close(context.Background()))
+	out, err = f.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+}
+
+func TestQueueEvents(t *testing.T) {
+	a := assert.New(t)
+	saturated := atomic.Int32{}
+	empty := atomic.Int32{}
+	drain := atomic.Int32{}
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return n, nil
+	}, async.QueueOptions{
+		Concurrency: 2,
+		OnSaturated: func() {
+			saturated.Add(1)
+		},
+		OnEmpty: func() {
+			empty.Add(1)
+		},
+		OnDrain: func() {
+			drain.Add(1)
+		},
+	})
+
+	q.Push(1)
+	a.EqualNow(saturated.Load(), 0)
+	a.EqualNow(empty.Load(), 1)
+	q.PushMany([]int{2, 3})
+	a.EqualNow(saturated.Load(), 1)
+	a.EqualNow(empty.Load(), 1)
+
+	time.Sleep(50 * time.Millisecond)
+	a.EqualNow(empty.Load(), 2)
+	a.EqualNow(drain.Load(), 1)
+
+	a.NilNow(q.Close(context.Background()))
+	a.EqualNow(drain.Load(), 1)
+}
+
+func TestQueueClose(t *testing.T) {
+	a := assert.New(t)
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return n, nil
+	})
+	f1 := q.Push(1)
+	f2 := q.Push(2)
+
+	start := time.Now()
+	a.NilNow(q.Close(context.Background()))
+	a.GteNow(time.Since(start), 40*time.Millisecond)
+
+	out, err := f2.Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+	_, err = f1.Await(context.Background())
+	a.NilNow(err)
+
+	_, err = q.Push(3).Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+	a.NilNow(q.Close(context.Background()))
+}
+
+func TestQueueCloseWithTimeout(t *testing.T) {
+	a := assert.New(t)
+	isCanceled := atomic.Bool{}
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		select {
+		case <-ctx.Done():
+			isCanceled.Store(true)
+			return 0, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return n, nil
+		}
+	})
+	f1 := q.Push(1)
+	f2 := q.Push(2)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer canFunc()
+	a.IsErrorNow(q.Close(ctx), async.ErrContextCanceled)
+
+	_, err := f1.Await(context.Background())
+	a.IsErrorNow(err, context.Canceled)
+	a.TrueNow(isCanceled.Load())
+	_, err = f2.Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+}
+
+func TestQueueWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithCancel(context.Background())
+	q := async.NewQueueWithContext(ctx, func(ctx context.Context, n int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	f1 := q.Push(1)
+	f2 := q.Push(2)
+
+	canFunc()
+	_, err := f1.Await(context.Background())
+	a.IsErrorNow(err, context.Canceled)
+	_, err = f2.Await(context.Background())
+	a.IsErrorNow(err, async.ErrQueueClosed)
+	a.NilNow(q.Close(context.Background()))
+}
+
+func TestQueueChainFutureAfterClose(t *testing.T) {
+	a := assert.New(t)
+
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	f := q.Push(1)
+	_, err := f.Await(context.Background())
+	a.NilNow(err)
+	a.NilNow(q.Close(context.Background()))
+
+	// the chained functions will not receive a canceled context after the queue is closed.
+	out, err := async.Then(f, func(ctx context.Context, n int) (int, error) {
+		return n + 1, ctx.Err()
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 2)
+
+	out, err = f.Finally(func(ctx context.Context) error {
+		return ctx.Err()
+	}).Await(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, 1)
+}
+
+func ExampleQueue() {
+	q := async.NewQueue(func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	}, async.QueueOptions{
+		Concurrency: 2,
+	})
+
+	futures := q.PushMany([]int{1, 2, 3})
+	for _, f := range futures {
+		out, err := f.Await(context.Background())
+		fmt.Println(out, err)
+	}
+	fmt.Println(q.Close(context.Background()))
+	// Output:
+	// 1 <nil>
+	// 4 <nil>
+	// 9 <nil>
+	// <nil>
+}
@@ -2,6 +2,7 @@ package async
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 )
 
@@ -40,19 +41,133 @@ func race(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
 	}
 	validateAsyncFuncs(funcs...)
 
-	ctx = getContext(ctx)
+	return raceTasks(ctx, len(funcs), asyncFnTask(funcs), false, false)
+}
+
+// RaceCancel executes the functions asynchronously, it will return the index and the result of the
+// first of the finished function (including panic), and send a cancel signal to all other
+// functions by context. It returns immediately after the first function is finished, and doesn't
+// wait for other functions to exit.
+//
+//	out, index, err := async.RaceCancel(func(ctx context.Context) (int, error) {
+//	  time.Sleep(50 * time.Millisecond)
+//	  return 1, nil
+//	}, func(ctx context.Context) (int, error) {
+//	  select {
+//	  case <-ctx.Done(): // the context will be canceled after the first function finished
+//	    return 0, ctx.Err()
+//	  case <-time.After(100 * time.Millisecond):
+//	    return 2, nil
+//	  }
+//	})
+//	// out: []any{1, <nil>}, index: 0, err: <nil>
+func RaceCancel(funcs ...AsyncFn) ([]any, int, error) {
+	return raceCancel(context.Background(), false, funcs...)
+}
+
+// RaceCancelWithContext executes the functions asynchronously with the specified context, it will
+// return the index and the result of the first of the finished function (including panic), and
+// send a cancel signal to all other functions by context. It returns immediately after the first
+// function is finished, and doesn't wait for other functions to exit.
+func RaceCancelWithContext(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
+	return raceCancel(ctx, false, funcs...)
+}
+
+// RaceCancelWait executes the functions asynchronously, it will return the index and the result of
+// the first of the finished function (including panic). Like RaceCancel, it sends a cancel signal
+// to all other functions by context after the first function is finished, but it'll wait for all
+// other functions to exit before returning.
+func RaceCancelWait(funcs ...AsyncFn) ([]any, int, error) {
+	return raceCancel(context.Background(), true, funcs...)
+}
+
+// RaceCancelWaitWithContext executes the functions asynchronously with the specified context, it
+// will return the index and the result of the first of the finished function (including panic).
+// It sends a cancel signal to all other functions by context after the first function is finished,
+// and waits for all other functions to exit before returning.
+func RaceCancelWaitWithContext(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
+	return raceCancel(ctx, true, funcs...)
+}
+
+// raceCancel executes the functions asynchronously, it will return the index and the result of the
+// first of the finished function (including panic), and cancel the other functions.
+func raceCancel(ctx context.Context, isWait bool, funcs ...AsyncFn) ([]any, int, error) {
+	if len(funcs) == 0 {
+		return nil, -1, nil
+	}
+	validateAsyncFuncs(funcs...)
+
+	return raceTasks(ctx, len(funcs), asyncFnTask(funcs), true, isWait)
+}
+
+// RaceOf executes the typed functions asynchronously, it will return the index and the result of
+// the first of the finished function (including panic), and it will not send a cancel signal to
+// other functions. It calls the functions directly without the reflection.
+//
+//	out, index, err := async.RaceOf(func(ctx context.Context) (int, error) {
+//	  time.Sleep(50 * time.Millisecond)
+//	  return 1, nil
+//	}, func(ctx context.Context) (int, error) {
+//	  time.Sleep(20 * time.Millisecond)
+//	  return 2, nil
+//	})
+//	// out: 2, index: 1, err: <nil>
+func RaceOf[T any](funcs ...func(context.Context) (T, error)) (T, int, error) {
+	return raceOf(context.Background(), funcs...)
+}
+
+// RaceOfWithContext executes the typed functions asynchronously with the specified context, it
+// will return the index and the result of the first of the finished function (including panic),
+// and it will not send a cancel signal to other functions.
+func RaceOfWithContext[T any](
+	ctx context.Context,
+	funcs ...func(context.Context) (T, error),
+) (T, int, error) {
+	return raceOf(ctx, funcs...)
+}
+
+// raceOf executes the typed functions asynchronously, it will return the index and the result of
+// the first of the finished function (including panic).
+func raceOf[T any](ctx context.Context, funcs ...func(context.Context) (T, error)) (T, int, error) {
+	if len(funcs) == 0 {
+		var out T
+		return out, -1, nil
+	}
+	validateTypedFuncs(funcs...)
+
+	return raceTasks(ctx, len(funcs), typedFnTask(funcs), false, false)
+}
+
+// raceTasks runs the number of tasks asynchronously, and returns the index and the result of the
+// first of the finished task. If isCancel is true, it will send a cancel signal to the other tasks
+// by context after the first task is finished, and it'll also wait for the other tasks to exit if
+// isWait is true.
+func raceTasks[T any](
+	parent context.Context,
+	num int,
+	fn taskFn[T],
+	isCancel, isWait bool,
+) (T, int, error) {
+	ctx := getContext(parent)
+	canFunc := context.CancelFunc(func() {})
+	if isCancel {
+		ctx, canFunc = context.WithCancel(ctx)
+	}
+	defer canFunc()
 
 	finished := atomic.Bool{}
-	ch := make(chan executeResult)
+	wg := sync.WaitGroup{}
+	ch := make(chan executeResult[T])
 	defer close(ch)
 
-	for i := 0; i < len(funcs); i++ {
+	wg.Add(num)
+	for i := 0; i < num; i++ {
 		go func(n int) {
-			fn := funcs[n]
+			defer wg.Done()
 
-			ret, err := invokeAsyncFn(fn, ctx, nil)
+			ret, err := fn(ctx, n)
 			if finished.CompareAndSwap(false, true) {
-				ch <- executeResult{
+				ch <- executeResult[T]{
 					Index: n,
 					Error: err,
 					Out:   ret,
@@ -62,6 +177,11 @@ func race(ctx context.Context, funcs ...AsyncFn) ([]any, int, error) {
 	}
 
 	ret := <-ch
+	canFunc()
+	if isWait {
+		wg.Wait()
+	}
+
 	if ret.Error != nil {
 		return ret.Out, ret.Index, &executionError{
 			index: ret.Index,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -148,3 +149,215 @@ func ExampleRace() {
 	// 1
 	// <nil>
 }
+
+func TestRaceCancel(t *testing.T) {
+	a := assert.New(t)
+
+	canceled := atomic.Int32{}
+	funcs := make([]async.AsyncFn, 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			select {
+			case <-ctx.Done():
+				time.Sleep(50 * time.Millisecond)
+				canceled.Add(1)
+				return n, ctx.Err()
+			case <-time.After(time.Duration((n+1)*50) * time.Millisecond):
+				return n, nil
+			}
+		})
+	}
+
+	out, index, err := async.RaceCancel(funcs...)
+	a.NilNow(err)
+	a.EqualNow(index, 0)
+	a.EqualNow(out, []any{0, nil})
+	a.EqualNow(canceled.Load(), 0)
+
+	time.Sleep(100 * time.Millisecond)
+	a.EqualNow(canceled.Load(), 4)
+}
+
+func TestRaceCancelWithoutFuncs(t *testing.T) {
+	a := assert.New(t)
+
+	out, index, err := async.RaceCancel()
+	a.NilNow(err)
+	a.EqualNow(index, -1)
+	a.NilNow(out)
+}
+
+func TestRaceCancelWithFailed(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, index, err := async.RaceCancel(func(ctx context.Context) error {
+		return expectedErr
+	}, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 0 error: expected error")
+	a.EqualNow(index, 0)
+	a.EqualNow(out, []any{expectedErr})
+}
+
+func TestRaceCancelWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	out, index, err := async.RaceCancelWithContext(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	a.IsErrorNow(err, context.DeadlineExceeded)
+	a.EqualNow(index, 0)
+	a.EqualNow(out, []any{context.DeadlineExceeded})
+}
+
+func TestRaceCancelWait(t *testing.T) {
+	a := assert.New(t)
+
+	canceled := atomic.Int32{}
+	funcs := make([]async.AsyncFn, 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			select {
+			case <-ctx.Done():
+				time.Sleep(50 * time.Millisecond)
+				canceled.Add(1)
+				return n, ctx.Err()
+			case <-time.After(time.Duration((n+1)*50) * time.Millisecond):
+				return n, nil
+			}
+		})
+	}
+
+	start := time.Now()
+	out, index, err := async.RaceCancelWait(funcs...)
+	a.NilNow(err)
+	a.EqualNow(index, 0)
+	a.EqualNow(out, []any{0, nil})
+	a.EqualNow(canceled.Load(), 4)
+	a.GteNow(time.Since(start), 100*time.Millisecond)
+}
+
+func TestRaceCancelWaitWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	exited := atomic.Bool{}
+	//lint:ignore SA1012 for test case only
+	out, index, err := async.RaceCancelWaitWithContext(nil, func() int {
+		return 1
+	}, func(ctx context.Context) int {
+		<-ctx.Done()
+		exited.Store(true)
+		return 2
+	})
+	a.NilNow(err)
+	a.EqualNow(index, 0)
+	a.EqualNow(out, []any{1})
+	a.TrueNow(exited.Load())
+}
+
+func ExampleRaceCancel() {
+	out, index, err := async.RaceCancel(func(ctx context.Context) int {
+		time.Sleep(20 * time.Millisecond)
+		return 1
+	}, func(ctx context.Context) int {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(50 * time.Millisecond):
+			return 2
+		}
+	})
+	fmt.Println(out)
+	fmt.Println(index)
+	fmt.Println(err)
+	// Output:
+	// [1]
+	// 0
+	// <nil>
+}
+
+func TestRaceOfWithoutFuncs(t *testing.T) {
+	a := assert.New(t)
+
+	out, index, err := async.RaceOf[int]()
+	a.NilNow(err)
+	a.EqualNow(index, -1)
+	a.EqualNow(out, 0)
+}
+
+func TestRaceOf(t *testing.T) {
+	a := assert.New(t)
+
+	funcs := make([]func(context.Context) (int, error), 0, 5)
+	for i := 0; i < 5; i++ {
+		n := i
+		funcs = append(funcs, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration((5-n)*30) * time.Millisecond)
+			return n, nil
+		})
+	}
+
+	out, index, err := async.RaceOf(funcs...)
+	a.NilNow(err)
+	a.EqualNow(index, 4)
+	a.EqualNow(out, 4)
+}
+
+func TestRaceOfWithFailed(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	out, index, err := async.RaceOf(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	}, func(ctx context.Context) (int, error) {
+		return 2, expectedErr
+	})
+	a.NotNilNow(err)
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+	a.EqualNow(index, 1)
+	a.EqualNow(out, 2)
+}
+
+func TestRaceOfWithNilContext(t *testing.T) {
+	a := assert.New(t)
+
+	//lint:ignore SA1012 for test case only
+	out, index, err := async.RaceOfWithContext(nil, func(ctx context.Context) (string, error) {
+		return "hello", nil
+	})
+	a.NilNow(err)
+	a.EqualNow(index, 0)
+	a.EqualNow(out, "hello")
+}
+
+func ExampleRaceOf() {
+	out, index, err := async.RaceOf(func(ctx context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	}, func(ctx context.Context) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 2, nil
+	})
+	fmt.Println(out)
+	fmt.Println(index)
+	fmt.Println(err)
+	// Output:
+	// 2
+	// 1
+	// <nil>
+}
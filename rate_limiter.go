@@ -0,0 +1,103 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket to limit the rate of the tasks, it can be shared by multiple
+// callers to limit the total throughput to a downstream service. Each task takes a token from the
+// bucket before it runs, and the tokens will be refilled at the specified rate. Unlike Semaphore
+// that limits the number of the running tasks, RateLimiter limits the number of the tasks that
+// start in a period.
+//
+//	// Allows 10 requests per second with 5 burst requests.
+//	limiter := async.NewRateLimiter(10, 5)
+//	for _, req := range requests {
+//	  if err := limiter.Wait(ctx); err != nil {
+//	    return err
+//	  }
+//	  Send(req)
+//	}
+type RateLimiter struct {
+	locker sync.Mutex
+	bucket tokenBucket
+}
+
+// NewRateLimiter creates a rate limiter that refills the tokens at the specified rate per second,
+// and holds no more than the burst number of tokens. The bucket is full when it's created. It'll
+// panic if the rate is less than or equal to 0, or the burst is less than 1.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 || burst < 1 {
+		panic(ErrInvalidRateLimit)
+	}
+
+	return &RateLimiter{
+		bucket: newTokenBucket(rate, burst),
+	}
+}
+
+// Wait takes a token from the limiter, and it blocks until the token is available or the context
+// is done. It returns ErrContextCanceled without taking the token if the context is done (canceled
+// or timeout) before the token is available.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	ctx = getContext(ctx)
+
+	l.locker.Lock()
+	l.bucket.refill()
+	// takes the token in advance, the waiters will get the tokens in the order they called Wait.
+	l.bucket.tokens--
+	delay := time.Duration(-l.bucket.tokens / l.bucket.rate * float64(time.Second))
+	l.locker.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.locker.Lock()
+		defer l.locker.Unlock()
+
+		l.bucket.refill()
+		l.bucket.tokens++
+		if l.bucket.tokens > l.bucket.burst {
+			l.bucket.tokens = l.bucket.burst
+		}
+
+		return ErrContextCanceled
+	}
+}
+
+// TryAcquire tries to take a token from the limiter without blocking, it returns false if there is
+// no available token.
+func (l *RateLimiter) TryAcquire() bool {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.bucket.refill()
+	if l.bucket.tokens < 1 {
+		return false
+	}
+
+	l.bucket.tokens--
+	return true
+}
+
+// Available returns the number of the available tokens in the limiter.
+func (l *RateLimiter) Available() int {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.bucket.refill()
+	if l.bucket.tokens < 0 {
+		return 0
+	}
+	return int(l.bucket.tokens)
+}
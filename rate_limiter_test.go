@@ -0,0 +1,87 @@
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestRateLimiter(t *testing.T) {
+	a := assert.New(t)
+
+	limiter := async.NewRateLimiter(20, 2)
+	a.EqualNow(limiter.Available(), 2)
+	a.TrueNow(limiter.TryAcquire())
+	a.TrueNow(limiter.TryAcquire())
+	a.NotTrueNow(limiter.TryAcquire())
+	a.EqualNow(limiter.Available(), 0)
+
+	time.Sleep(60 * time.Millisecond)
+	a.EqualNow(limiter.Available(), 1)
+	a.TrueNow(limiter.TryAcquire())
+	a.NotTrueNow(limiter.TryAcquire())
+}
+
+func TestNewRateLimiterWithInvalidParameters(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewRateLimiter(0, 1)
+	}, async.ErrInvalidRateLimit)
+	a.PanicOfNow(func() {
+		async.NewRateLimiter(-1, 1)
+	}, async.ErrInvalidRateLimit)
+	a.PanicOfNow(func() {
+		async.NewRateLimiter(1, 0)
+	}, async.ErrInvalidRateLimit)
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	a := assert.New(t)
+
+	limiter := async.NewRateLimiter(50, 1)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		a.NilNow(limiter.Wait(context.Background()))
+	}
+	dur := time.Since(start)
+	a.GteNow(dur, 55*time.Millisecond)
+	a.LtNow(dur, 80*time.Millisecond)
+}
+
+func TestRateLimiterWaitWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	limiter := async.NewRateLimiter(10, 1)
+	a.NilNow(limiter.Wait(context.Background()))
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	start := time.Now()
+	a.IsErrorNow(limiter.Wait(ctx), async.ErrContextCanceled)
+	a.LtNow(time.Since(start), 30*time.Millisecond)
+
+	// the canceled waiter gives the token back.
+	time.Sleep(100 * time.Millisecond)
+	a.TrueNow(limiter.TryAcquire())
+}
+
+func ExampleRateLimiter() {
+	limiter := async.NewRateLimiter(100, 2)
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(i)
+	}
+	// Output:
+	// 0
+	// 1
+	// 2
+}
@@ -0,0 +1,176 @@
+package async
+
+import "context"
+
+// Reduce runs the function with each element in the slice one at a time, and each function
+// consumes the accumulated value that returned by the previous function, it starts with the
+// initial value. It returns the accumulated value that returned by the last function. If the
+// function returns an error or panics with any element, it will return the accumulated value
+// before the element and an execution error that the index is the element's index in the slice,
+// and no more functions are run.
+//
+//	sum, err := async.Reduce([]int{1, 2, 3}, 0, func(ctx context.Context, acc, n int) (int, error) {
+//	  return acc + n, nil
+//	})
+//	// sum: 6
+//	// err: <nil>
+func Reduce[T, Acc any](
+	in []T,
+	initial Acc,
+	fn func(context.Context, Acc, T) (Acc, error),
+) (Acc, error) {
+	return reduce(context.Background(), in, initial, fn)
+}
+
+// ReduceWithContext runs the function with each element in the slice one at a time with the
+// specified context, and each function consumes the accumulated value that returned by the
+// previous function. It returns the accumulated value that returned by the last function, or
+// it terminates and returns an execution error if the function returns an error or panics with
+// any element.
+func ReduceWithContext[T, Acc any](
+	ctx context.Context,
+	in []T,
+	initial Acc,
+	fn func(context.Context, Acc, T) (Acc, error),
+) (Acc, error) {
+	return reduce(ctx, in, initial, fn)
+}
+
+// reduce runs the function with each element in the slice in order, and carries the accumulated
+// value to the next function.
+func reduce[T, Acc any](
+	parent context.Context,
+	in []T,
+	initial Acc,
+	fn func(context.Context, Acc, T) (Acc, error),
+) (Acc, error) {
+	if fn == nil {
+		panic(ErrNotFunction)
+	}
+
+	ctx := getContext(parent)
+	acc := initial
+
+	for i, v := range in {
+		ret, err := invokeTypedFn(func(ctx context.Context) (Acc, error) {
+			return fn(ctx, acc, v)
+		}, ctx)
+		if err != nil {
+			return acc, &executionError{
+				index: i,
+				err:   err,
+			}
+		}
+		acc = ret
+	}
+
+	return acc, nil
+}
+
+// ParallelReduce combines the elements in the slice pairwise by the function asynchronously with
+// the specified concurrency limit, and the combined values will be combined pairwise again until
+// there is only one value left. The function must be associative because the elements are not
+// combined in order from left to right, but the order of the operands is kept. It returns the zero
+// value for an empty slice, and it returns the only element without calling the function for a
+// slice with one element.
+//
+// If the function returns an error or panics, it will immediately return an execution error that
+// the index is the index of the first element in the slice that the left operand covers, and send
+// a cancel signal to all other functions by context.
+//
+//	// Runs 2 functions at the same time.
+//	in := []int{1, 2, 3, 4, 5}
+//	sum, err := async.ParallelReduce(in, 2, func(ctx context.Context, x, y int) (int, error) {
+//	  return x + y, nil
+//	})
+//	// sum: 15
+//	// err: <nil>
+func ParallelReduce[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T, T) (T, error),
+) (T, error) {
+	return parallelReduce(context.Background(), in, concurrency, fn)
+}
+
+// ParallelReduceWithContext combines the elements in the slice pairwise by the function
+// asynchronously with the specified context and the concurrency limit, and the combined values
+// will be combined pairwise again until there is only one value left. The function must be
+// associative. If the function returns an error or panics, it will immediately return an
+// execution error, and send a cancel signal to all other functions by context.
+func ParallelReduceWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T, T) (T, error),
+) (T, error) {
+	return parallelReduce(ctx, in, concurrency, fn)
+}
+
+// parallelReduce combines the values pairwise round by round with the specified concurrency until
+// there is only one value left.
+func parallelReduce[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T, T) (T, error),
+) (T, error) {
+	var out T
+	if fn == nil {
+		panic(ErrNotFunction)
+	} else if len(in) == 0 {
+		return out, nil
+	}
+
+	paralleler := builtinPool.Get().(*Paralleler)
+	defer func() {
+		builtinPool.Put(paralleler)
+	}()
+
+	paralleler.
+		WithContext(parent).
+		WithConcurrency(concurrency)
+
+	vals := in
+	// indexes are the indexes of the first elements in the slice that the values cover.
+	indexes := make([]int, len(in))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for len(vals) > 1 {
+		pairs := len(vals) / 2
+		ret, err := runTasks(paralleler, pairs, pairTask(vals, fn))
+		if err != nil {
+			if execErr, ok := err.(*executionError); ok {
+				execErr.index = indexes[execErr.index*2]
+			}
+			return out, err
+		}
+
+		nextIndexes := make([]int, 0, pairs+1)
+		for i := 0; i < pairs; i++ {
+			nextIndexes = append(nextIndexes, indexes[i*2])
+		}
+		if len(vals)%2 == 1 {
+			// carries the last value to the next round.
+			ret = append(ret, vals[len(vals)-1])
+			nextIndexes = append(nextIndexes, indexes[len(vals)-1])
+		}
+
+		vals = ret
+		indexes = nextIndexes
+	}
+
+	return vals[0], nil
+}
+
+// pairTask returns a taskFn that combines the n-th pair of the values by the function, the panic
+// of the function will be caught and returned as an error.
+func pairTask[T any](vals []T, fn func(context.Context, T, T) (T, error)) taskFn[T] {
+	return func(ctx context.Context, n int) (T, error) {
+		return invokeTypedFn(func(ctx context.Context) (T, error) {
+			return fn(ctx, vals[n*2], vals[n*2+1])
+		}, ctx)
+	}
+}
@@ -0,0 +1,212 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestReduce(t *testing.T) {
+	a := assert.New(t)
+
+	in := []int{1, 2, 3, 4}
+	out, err := async.Reduce(in, "", func(ctx context.Context, acc string, n int) (string, error) {
+		return fmt.Sprintf("%s%d", acc, n), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "1234")
+
+	out, err = async.Reduce(nil, "init", func(ctx context.Context, acc string, n int) (string, error) {
+		return "", nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "init")
+}
+
+func TestReduceWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Reduce[int, int]([]int{1}, 0, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestReduceWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	calls := make([]int, 0, 3)
+
+	in := []int{1, 2, 3, 4}
+	out, err := async.Reduce(in, 0, func(ctx context.Context, acc, n int) (int, error) {
+		calls = append(calls, n)
+		if n == 3 {
+			return 0, expectedErr
+		}
+		return acc + n, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 2 error: expected error")
+	a.EqualNow(out, 3)
+	a.EqualNow(calls, []int{1, 2, 3})
+
+	_, err = async.Reduce(in, 0, func(ctx context.Context, acc, n int) (int, error) {
+		panic("expected panic")
+	})
+	a.EqualNow(err.Error(), "function 0 error: expected panic")
+}
+
+func TestReduceWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx := context.WithValue(context.Background(), "key", 10)
+	in := []int{1, 2}
+	out, err := async.ReduceWithContext(ctx, in, 0, func(ctx context.Context, x, n int) (int, error) {
+		return x + n*ctx.Value("key").(int), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, 30)
+}
+
+func ExampleReduce() {
+	in := []int{1, 2, 3}
+	sum, err := async.Reduce(in, 0, func(ctx context.Context, acc, n int) (int, error) {
+		return acc + n, nil
+	})
+	fmt.Println(sum)
+	fmt.Println(err)
+	// Output:
+	// 6
+	// <nil>
+}
+
+func TestParallelReduce(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	in := []string{"a", "b", "c", "d", "e"}
+	out, err := async.ParallelReduce(in, 2, func(ctx context.Context, x, y string) (string, error) {
+		cnt.Add(1)
+		return x + y, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "abcde")
+	a.EqualNow(cnt.Load(), 4)
+
+	out, err = async.ParallelReduce(nil, 2, func(ctx context.Context, x, y string) (string, error) {
+		return x + y, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "")
+
+	in = []string{"a"}
+	out, err = async.ParallelReduce(in, 2, func(ctx context.Context, x, y string) (string, error) {
+		return "", errors.New("unexpected call")
+	})
+	a.NilNow(err)
+	a.EqualNow(out, "a")
+}
+
+func TestParallelReduceWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.ParallelReduce[int]([]int{1}, 1, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestParallelReduceConcurrency(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := make([]int, 16)
+	for i := range in {
+		in[i] = i + 1
+	}
+
+	start := time.Now()
+	out, err := async.ParallelReduce(in, 4, func(ctx context.Context, x, y int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return x + y, nil
+	})
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(out, 136)
+	a.EqualNow(maxRunning.Load(), 4)
+	// 8 pairs in 2 batches, and 4, 2, 1 pairs in the next rounds.
+	a.GteNow(dur, 100*time.Millisecond)
+	a.LtNow(dur, 150*time.Millisecond)
+}
+
+func TestParallelReduceWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	in := []int{1, 2, 3, 4, 5, 6}
+	_, err := async.ParallelReduce(in, 0, func(ctx context.Context, x, y int) (int, error) {
+		if x == 5 {
+			return 0, expectedErr
+		}
+		return x + y, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 4 error: expected error")
+
+	// the second round combines [3, 7, 11], and 3 + 7 covers the elements from index 0.
+	_, err = async.ParallelReduce(in, 0, func(ctx context.Context, x, y int) (int, error) {
+		if x == 3 && y == 7 {
+			panic("expected panic")
+		}
+		return x + y, nil
+	})
+	a.EqualNow(err.Error(), "function 0 error: expected panic")
+
+	var execErr async.ExecutionError
+	a.TrueNow(errors.As(err, &execErr))
+	a.EqualNow(execErr.Index(), 0)
+}
+
+func TestParallelReduceWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	_, err := async.ParallelReduceWithContext(
+		ctx,
+		[]int{1, 2, 3, 4},
+		1,
+		func(ctx context.Context, x, y int) (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return x + y, nil
+		},
+	)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleParallelReduce() {
+	in := []int{1, 2, 3, 4, 5}
+	sum, err := async.ParallelReduce(in, 2, func(ctx context.Context, x, y int) (int, error) {
+		return x + y, nil
+	})
+	fmt.Println(sum)
+	fmt.Println(err)
+	// Output:
+	// 15
+	// <nil>
+}
@@ -2,6 +2,7 @@ package async
 
 import (
 	"context"
+	"reflect"
 	"time"
 )
 
@@ -18,16 +19,58 @@ type RetryOptions struct {
 	// IntervalFunc is the function to calculate the time to wait between retries in milliseconds, it
 	// accepts an int value to indicate the retry count.
 	IntervalFunc func(int) int
+	// Delay is the duration to wait between retries, it'll be used instead of Interval if it's
+	// greater than 0.
+	Delay time.Duration
+	// Backoff is the function to calculate the duration to wait between retries, like
+	// ExponentialBackoff, FullJitterBackoff, or DecorrelatedJitterBackoff. It takes precedence over
+	// IntervalFunc, Delay, and Interval.
+	Backoff BackoffFunc
 	// ErrorFilter is a function that is invoked on an error result. Retry will continue the retry
 	// attempts if it returns true, and it will abort the workflow and return the current attempt's
-	// result and error if it returns false.
+	// result and a RetryError that wraps the current attempt's error if it returns false.
 	ErrorFilter func(error) bool
+	// RetryIf is a function that is invoked on every attempt's result, it accepts all of the return
+	// values of the function (including the last error if the function returns an error) and the
+	// error of the attempt. Retry will continue the retry attempts if it returns true, even if the
+	// attempt succeeded without error, and the attempt will be recorded with ErrUnacceptableResult.
+	// Retry will abort the workflow and return the current attempt's result if it returns false,
+	// with a RetryError that wraps the attempt's error if the attempt failed. It takes precedence
+	// over ErrorFilter.
+	RetryIf func(out []any, err error) bool
+	// MaxElapsed is the total time budget of all attempts and the waiting between them since the
+	// first attempt started. Every attempt will receive a child context that is bound to the
+	// deadline of the budget, and the running attempt will be failed with ErrAttemptTimeout after
+	// the deadline without waiting for it to exit. Retry will give up if the budget is exceeded, or
+	// the next attempt will start after the deadline. There is no limitation if it's less than or
+	// equal to 0.
+	MaxElapsed time.Duration
+	// Budget is the token bucket that limits the number of retries, it can be shared by multiple
+	// Retry callers to limit the total retries. Retry will give up if there is no available token
+	// in the budget before making the next attempt.
+	Budget *RetryBudget
+	// AttemptTimeout is the timeout of each attempt, every attempt will receive a child context
+	// that is bound to the deadline. The attempt that timed out will be failed with
+	// ErrAttemptTimeout and can be retried, and Retry will not wait for the timed out attempt to
+	// exit. There is no limitation if it's less than or equal to 0.
+	AttemptTimeout time.Duration
+	// OnRetry is the function that is invoked after an attempt failed and before waiting for the
+	// next attempt. It accepts the number of the failed attempt (starts from 1), the error of the
+	// attempt, and the duration to wait before the next attempt.
+	OnRetry func(attempt int, err error, nextDelay time.Duration)
+	// OnGiveUp is the function that is invoked when Retry gives up, it accepts the number of the
+	// attempts that have been made and the RetryError that Retry will return.
+	OnGiveUp func(attempts int, err error)
 }
 
 // Retry attempts to get a successful response from the function with no more than the specific
 // retry times before returning an error. If the task is successful, it will return the result of
-// the successful task. If all attempts fail, it will return the result and the error of the final
-// attempt.
+// the successful task. If all attempts fail, it will return the result of the final attempt and a
+// RetryError that records the error and the timing of every attempt.
+//
+// The returned error is always a RetryError that wraps the error of the final attempt, even if the
+// workflow is aborted by ErrorFilter or RetryIf, so please use errors.Is or errors.As instead of
+// comparing the error directly.
 //
 //	async.Retry(func() error {
 //	  // Do something
@@ -41,6 +84,13 @@ type RetryOptions struct {
 //	  Times: 3,
 //	  Interval: 100,
 //	}) // Run the function 3 times with 100ms interval or it succeed
+//
+//	async.Retry(func() error {
+//	  // Do something
+//	  return err
+//	}, RetryOptions{
+//	  Backoff: async.ExponentialBackoff(100*time.Millisecond, time.Second),
+//	}) // Run the function 5 times with exponential backoff intervals or it succeed
 func Retry(fn AsyncFn, opts ...RetryOptions) ([]any, error) {
 	return retry(context.Background(), fn, opts...)
 }
@@ -48,49 +98,98 @@ func Retry(fn AsyncFn, opts ...RetryOptions) ([]any, error) {
 // RetryWithContext runs the function with the specified context, and attempts to get a successful
 // response from the function with no more than the specific retry times before returning an error.
 // If the task is successful, it will return the result of the successful task. If all attempts
-// fail, it will return the result and the error of the final attempt.
+// fail, it will return the result of the final attempt and a RetryError that records the error and
+// the timing of every attempt. If the context is done (canceled or timeout), it will not make the
+// next attempt and return immediately, and the RetryError will wrap the context's error. Like
+// Retry, the returned error is always a RetryError, please use errors.Is or errors.As to check it.
 func RetryWithContext(ctx context.Context, fn AsyncFn, opts ...RetryOptions) ([]any, error) {
 	return retry(ctx, fn, opts...)
 }
 
 // retry runs the function and attempts to get a successful response from the function with no more
 // than the specific retry times before returning an error.
-func retry(parent context.Context, fn AsyncFn, opts ...RetryOptions) (out []any, err error) {
+func retry(parent context.Context, fn AsyncFn, opts ...RetryOptions) ([]any, error) {
 	validateAsyncFuncs(fn)
 	ctx := getContext(parent)
 	opt := getRetryOption(opts...)
+	attempts := make([]RetryAttempt, 0, opt.Times)
+	var out []any
+	var err error
+	var reason error
+	var delay time.Duration
+	startTime := time.Now()
+
+	elapsedCtx := ctx
+	if opt.MaxElapsed > 0 {
+		// bounds the running attempts by the deadline of the total time budget.
+		var canFunc context.CancelFunc
+		elapsedCtx, canFunc = context.WithDeadline(ctx, startTime.Add(opt.MaxElapsed))
+		defer canFunc()
+	}
 
 	for i := 1; i <= opt.Times; i++ {
-		out, err = invokeAsyncFn(fn, ctx, nil)
+		start := time.Now()
+		out, err = opt.invokeAttempt(ctx, elapsedCtx, fn)
+		isRetry := opt.shouldRetry(out, err)
 		if err == nil {
-			return
-		} else if opt.ErrorFilter != nil && !opt.ErrorFilter(err) {
-			return
+			if !isRetry {
+				return out, nil
+			}
+			err = ErrUnacceptableResult
 		}
 
-		if i != opt.Times {
-			interval := opt.Interval
-			if opt.IntervalFunc != nil {
-				interval = opt.IntervalFunc(i)
-			}
+		attempts = append(attempts, RetryAttempt{
+			Attempt:   i,
+			Err:       err,
+			StartTime: start,
+			Duration:  time.Since(start),
+		})
 
-			if interval != 0 {
-				time.Sleep(time.Duration(interval) * time.Millisecond)
-			}
+		if !isRetry || i == opt.Times {
+			break
+		} else if ctxErr := ctx.Err(); ctxErr != nil {
+			// the parent context is done, no more attempts
+			err = ctxErr
+			break
+		}
+
+		delay = opt.getDelay(i, delay)
+		if opt.MaxElapsed > 0 && time.Since(startTime)+delay > opt.MaxElapsed {
+			reason = ErrMaxElapsedExceeded
+			break
+		} else if opt.Budget != nil && !opt.Budget.TryAcquire() {
+			reason = ErrRetryBudgetExhausted
+			break
+		}
+
+		attempts[len(attempts)-1].Delay = delay
+		if opt.OnRetry != nil {
+			opt.OnRetry(i, err, delay)
+		}
+
+		if waitErr := sleepWithContext(ctx, delay); waitErr != nil {
+			err = waitErr
+			break
 		}
 	}
 
-	return
+	retryErr := &RetryError{
+		Attempts: attempts,
+		Err:      err,
+		Reason:   reason,
+	}
+	if opt.OnGiveUp != nil {
+		opt.OnGiveUp(len(attempts), retryErr)
+	}
+
+	return out, retryErr
 }
 
 // getRetryOption gets the retry option by the customize option or the default values.
 func getRetryOption(opts ...RetryOptions) RetryOptions {
 	opt := RetryOptions{}
 	if len(opts) > 0 {
-		opt.Interval = opts[0].Interval
-		opt.Times = opts[0].Times
-		opt.IntervalFunc = opts[0].IntervalFunc
-		opt.ErrorFilter = opts[0].ErrorFilter
+		opt = opts[0]
 	}
 
 	if opt.Interval <= 0 {
@@ -102,3 +201,94 @@ func getRetryOption(opts ...RetryOptions) RetryOptions {
 
 	return opt
 }
+
+// invokeAttempt invokes the function with a child context that is bound to the attempt timeout and
+// the deadline of the max elapsed time. It returns ErrAttemptTimeout if the attempt is timed out,
+// and it returns the parent context's error if the parent context is done before the attempt
+// finished.
+func (opt *RetryOptions) invokeAttempt(
+	ctx, elapsedCtx context.Context,
+	fn AsyncFn,
+) ([]any, error) {
+	if opt.AttemptTimeout <= 0 && opt.MaxElapsed <= 0 {
+		return invokeAsyncFn(fn, ctx, nil)
+	}
+
+	attemptCtx := elapsedCtx
+	if opt.AttemptTimeout > 0 {
+		var canFunc context.CancelFunc
+		attemptCtx, canFunc = context.WithTimeout(elapsedCtx, opt.AttemptTimeout)
+		defer canFunc()
+	}
+
+	// makes the parameters before starting the attempt, so the unmatched parameters will panic in
+	// the caller's goroutine.
+	in := makeFuncIn(reflect.TypeOf(fn), attemptCtx, nil)
+
+	ch := make(chan executeResult[[]any], 1)
+	go func() {
+		out, err := callAsyncFn(fn, in)
+		ch <- executeResult[[]any]{
+			Error: err,
+			Out:   out,
+		}
+	}()
+
+	select {
+	case ret := <-ch:
+		if ret.Error != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+			return ret.Out, ErrAttemptTimeout
+		}
+		return ret.Out, ret.Error
+	case <-attemptCtx.Done():
+		out := makeZeroFuncOut(reflect.TypeOf(fn))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return out, ctxErr
+		}
+		return out, ErrAttemptTimeout
+	}
+}
+
+// shouldRetry returns a boolean value to indicate whether to make the next attempt or not by the
+// result of the current attempt.
+func (opt *RetryOptions) shouldRetry(out []any, err error) bool {
+	if opt.RetryIf != nil {
+		return opt.RetryIf(out, err)
+	} else if err == nil {
+		return false
+	}
+
+	return opt.ErrorFilter == nil || opt.ErrorFilter(err)
+}
+
+// getDelay returns the duration to wait before the next attempt by the backoff function, the
+// interval function, or the fixed delay.
+func (opt *RetryOptions) getDelay(attempt int, prev time.Duration) time.Duration {
+	if opt.Backoff != nil {
+		return opt.Backoff(attempt, prev)
+	} else if opt.IntervalFunc != nil {
+		return time.Duration(opt.IntervalFunc(attempt)) * time.Millisecond
+	} else if opt.Delay > 0 {
+		return opt.Delay
+	}
+
+	return time.Duration(opt.Interval) * time.Millisecond
+}
+
+// sleepWithContext waits for the duration, and it'll return the context's error immediately if
+// the context is done (canceled or timeout) before the duration elapsed.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
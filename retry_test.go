@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -103,6 +104,142 @@ func TestRetryWithIntervalFunc(t *testing.T) {
 	a.LteNow(dur, 550*time.Millisecond) // allow 50ms deviation
 }
 
+func TestRetryWithDelay(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	start := time.Now()
+	out, err := async.Retry(func() (int, error) {
+		return 0, expectedErr
+	}, async.RetryOptions{
+		Times:    3,
+		Interval: 500,
+		Delay:    50 * time.Millisecond,
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(out, []any{0, expectedErr})
+
+	dur := time.Since(start)
+	a.GteNow(dur, 100*time.Millisecond)
+	a.LteNow(dur, 150*time.Millisecond) // allow 50ms deviation
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	delays := make([]time.Duration, 0, 3)
+
+	start := time.Now()
+	out, err := async.Retry(func() (int, error) {
+		return 0, expectedErr
+	}, async.RetryOptions{
+		Times: 4,
+		IntervalFunc: func(n int) int {
+			return 1000
+		},
+		Backoff: func(attempt int, prev time.Duration) time.Duration {
+			delays = append(delays, prev)
+			return async.ExponentialBackoff(20*time.Millisecond, 50*time.Millisecond)(attempt, prev)
+		},
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(out, []any{0, expectedErr})
+	a.EqualNow(delays, []time.Duration{0, 20 * time.Millisecond, 40 * time.Millisecond})
+
+	dur := time.Since(start)
+	a.GteNow(dur, 110*time.Millisecond)
+	a.LteNow(dur, 160*time.Millisecond) // allow 50ms deviation
+}
+
+func TestRetryWithContextCanceledWhileWaiting(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	i := 0
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	start := time.Now()
+	out, err := async.RetryWithContext(ctx, func() (int, error) {
+		i++
+		return i, expectedErr
+	}, async.RetryOptions{
+		Delay: time.Second,
+	})
+	a.IsErrorNow(err, context.DeadlineExceeded)
+	a.EqualNow(out, []any{1, expectedErr})
+	a.EqualNow(i, 1)
+	a.LtNow(time.Since(start), 100*time.Millisecond)
+}
+
+func TestRetryWithHooks(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	retries := make([]int, 0, 2)
+	delays := make([]time.Duration, 0, 2)
+	giveUpAttempts := 0
+	var giveUpErr error
+
+	out, err := async.Retry(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 0, expectedErr
+	}, async.RetryOptions{
+		Times: 3,
+		Delay: 20 * time.Millisecond,
+		OnRetry: func(attempt int, err error, nextDelay time.Duration) {
+			a.EqualNow(err, expectedErr)
+			retries = append(retries, attempt)
+			delays = append(delays, nextDelay)
+		},
+		OnGiveUp: func(attempts int, err error) {
+			giveUpAttempts = attempts
+			giveUpErr = err
+		},
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(out, []any{0, expectedErr})
+	a.EqualNow(retries, []int{1, 2})
+	a.EqualNow(delays, []time.Duration{20 * time.Millisecond, 20 * time.Millisecond})
+	a.EqualNow(giveUpAttempts, 3)
+	a.EqualNow(giveUpErr, err)
+	a.IsErrorNow(giveUpErr, expectedErr)
+
+	var retryErr *async.RetryError
+	a.TrueNow(errors.As(err, &retryErr))
+	a.EqualNow(len(retryErr.Attempts), 3)
+	for i, attempt := range retryErr.Attempts {
+		a.EqualNow(attempt.Attempt, i+1)
+		a.EqualNow(attempt.Err, expectedErr)
+		a.GteNow(attempt.Duration, 10*time.Millisecond)
+		if i > 0 {
+			prev := retryErr.Attempts[i-1]
+			a.GteNow(attempt.StartTime.Sub(prev.StartTime), prev.Duration+prev.Delay)
+		}
+	}
+	a.EqualNow(retryErr.Attempts[2].Delay, time.Duration(0))
+}
+
+func TestRetryWithHooksSucceeded(t *testing.T) {
+	a := assert.New(t)
+	i := 0
+	isGiveUp := false
+
+	out, err := async.Retry(func() (int, error) {
+		i++
+		if i != 2 {
+			return 0, errors.New("not 2")
+		}
+		return i, nil
+	}, async.RetryOptions{
+		OnGiveUp: func(attempts int, err error) {
+			isGiveUp = true
+		},
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []any{2, nil})
+	a.NotTrueNow(isGiveUp)
+}
+
 func TestRetryWithErrorFilter(t *testing.T) {
 	a := assert.New(t)
 	expectedErr := errors.New("expected error")
@@ -126,6 +263,241 @@ func TestRetryWithErrorFilter(t *testing.T) {
 	a.EqualNow(out, []any{expectedErr})
 }
 
+func TestRetryWithRetryIf(t *testing.T) {
+	a := assert.New(t)
+	i := 0
+
+	out, err := async.Retry(func() ([]int, error) {
+		i++
+		if i < 3 {
+			return []int{}, nil
+		}
+		return []int{i}, nil
+	}, async.RetryOptions{
+		RetryIf: func(out []any, err error) bool {
+			return err != nil || len(out[0].([]int)) == 0
+		},
+	})
+	a.NilNow(err)
+	a.EqualNow(out[0], []int{3})
+	a.NilNow(out[1])
+	a.EqualNow(i, 3)
+}
+
+func TestRetryWithRetryIfUnacceptable(t *testing.T) {
+	a := assert.New(t)
+	errs := make([]error, 0, 2)
+
+	out, err := async.Retry(func() (int, error) {
+		return 202, nil
+	}, async.RetryOptions{
+		Times: 3,
+		RetryIf: func(out []any, err error) bool {
+			return out[0].(int) == 202
+		},
+		OnRetry: func(attempt int, err error, nextDelay time.Duration) {
+			errs = append(errs, err)
+		},
+	})
+	a.IsErrorNow(err, async.ErrUnacceptableResult)
+	a.EqualNow(out, []any{202, nil})
+	a.EqualNow(errs, []error{async.ErrUnacceptableResult, async.ErrUnacceptableResult})
+}
+
+func TestRetryWithRetryIfAbort(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	i := 0
+
+	out, err := async.Retry(func() (int, error) {
+		i++
+		return i, expectedErr
+	}, async.RetryOptions{
+		ErrorFilter: func(err error) bool {
+			return true
+		},
+		RetryIf: func(out []any, err error) bool {
+			return out[0].(int) < 2
+		},
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(out, []any{2, expectedErr})
+	a.EqualNow(i, 2)
+}
+
+func TestRetryWithMaxElapsed(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	i := 0
+
+	start := time.Now()
+	out, err := async.Retry(func() (int, error) {
+		i++
+		return i, expectedErr
+	}, async.RetryOptions{
+		Times:      10,
+		Delay:      30 * time.Millisecond,
+		MaxElapsed: 100 * time.Millisecond,
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.IsErrorNow(err, async.ErrMaxElapsedExceeded)
+	a.NotIsErrorNow(err, async.ErrRetryBudgetExhausted)
+	a.EqualNow(out, []any{4, expectedErr})
+	a.EqualNow(i, 4)
+	a.LtNow(time.Since(start), 100*time.Millisecond)
+}
+
+func TestRetryWithMaxElapsedRunningAttempt(t *testing.T) {
+	a := assert.New(t)
+	i := atomic.Int32{}
+
+	start := time.Now()
+	out, err := async.Retry(func(ctx context.Context) (int, error) {
+		i.Add(1)
+		// hung attempt that ignores the context
+		time.Sleep(300 * time.Millisecond)
+		return 0, nil
+	}, async.RetryOptions{
+		MaxElapsed: 100 * time.Millisecond,
+	})
+	a.IsErrorNow(err, async.ErrMaxElapsedExceeded)
+	a.IsErrorNow(err, async.ErrAttemptTimeout)
+	a.EqualNow(out, []any{0, nil})
+	a.EqualNow(i.Load(), 1)
+	dur := time.Since(start)
+	a.GteNow(dur, 100*time.Millisecond)
+	a.LtNow(dur, 150*time.Millisecond)
+}
+
+func TestRetryWithBudget(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+	budget := async.NewRetryBudget(0, 3)
+	cnt := 0
+
+	fn := func() error {
+		cnt++
+		return expectedErr
+	}
+
+	_, err := async.Retry(fn, async.RetryOptions{
+		Times:  3,
+		Budget: budget,
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.NotIsErrorNow(err, async.ErrRetryBudgetExhausted)
+	a.EqualNow(cnt, 3)
+
+	_, err = async.Retry(fn, async.RetryOptions{
+		Times:  3,
+		Budget: budget,
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.IsErrorNow(err, async.ErrRetryBudgetExhausted)
+	a.EqualNow(err.Error(), "failed after 2 attempts (retry budget exhausted): expected error")
+	a.EqualNow(cnt, 5)
+
+	_, err = async.Retry(fn, async.RetryOptions{
+		Times:  3,
+		Budget: budget,
+	})
+	a.IsErrorNow(err, async.ErrRetryBudgetExhausted)
+	a.EqualNow(cnt, 6)
+}
+
+func TestRetryWithAttemptTimeout(t *testing.T) {
+	a := assert.New(t)
+	i := atomic.Int32{}
+
+	start := time.Now()
+	out, err := async.Retry(func(ctx context.Context) (int, error) {
+		n := int(i.Add(1))
+		if n < 3 {
+			// hung attempt that ignores the context
+			time.Sleep(time.Second)
+		}
+		return n, nil
+	}, async.RetryOptions{
+		AttemptTimeout: 50 * time.Millisecond,
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []any{3, nil})
+	a.LtNow(time.Since(start), 150*time.Millisecond)
+}
+
+func TestRetryWithAttemptTimeoutFailed(t *testing.T) {
+	a := assert.New(t)
+	errs := make([]error, 0, 2)
+
+	_, err := async.Retry(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, async.RetryOptions{
+		Times:          3,
+		AttemptTimeout: 20 * time.Millisecond,
+		OnRetry: func(attempt int, err error, nextDelay time.Duration) {
+			errs = append(errs, err)
+		},
+	})
+	a.IsErrorNow(err, async.ErrAttemptTimeout)
+	a.NotIsErrorNow(err, context.DeadlineExceeded)
+	a.EqualNow(errs, []error{async.ErrAttemptTimeout, async.ErrAttemptTimeout})
+}
+
+func TestRetryWithAttemptTimeoutAndRetryIf(t *testing.T) {
+	a := assert.New(t)
+	results := make([][]any, 0, 2)
+
+	out, err := async.Retry(func(ctx context.Context) (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	}, async.RetryOptions{
+		Times:          2,
+		AttemptTimeout: 20 * time.Millisecond,
+		RetryIf: func(out []any, err error) bool {
+			results = append(results, out)
+			return out[0] != 1
+		},
+	})
+	a.IsErrorNow(err, async.ErrAttemptTimeout)
+	a.EqualNow(out, []any{0, nil})
+	a.EqualNow(results, [][]any{{0, nil}, {0, nil}})
+}
+
+func TestRetryWithAttemptTimeoutAndUnmatchedParam(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Retry(func(ctx context.Context, n int) error {
+			return nil
+		}, async.RetryOptions{
+			AttemptTimeout: 20 * time.Millisecond,
+		})
+	}, async.ErrUnmatchedParam)
+}
+
+func TestRetryWithAttemptTimeoutAndParentCanceled(t *testing.T) {
+	a := assert.New(t)
+	i := atomic.Int32{}
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	start := time.Now()
+	out, err := async.RetryWithContext(ctx, func(ctx context.Context) (int, error) {
+		i.Add(1)
+		time.Sleep(time.Second)
+		return 0, nil
+	}, async.RetryOptions{
+		AttemptTimeout: 100 * time.Millisecond,
+	})
+	a.IsErrorNow(err, context.DeadlineExceeded)
+	a.NotIsErrorNow(err, async.ErrAttemptTimeout)
+	a.EqualNow(out, []any{0, nil})
+	a.EqualNow(i.Load(), 1)
+	a.LtNow(time.Since(start), 100*time.Millisecond)
+}
+
 func TestRetryWithContext(t *testing.T) {
 	a := assert.New(t)
 
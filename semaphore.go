@@ -0,0 +1,202 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Semaphore is a weighted semaphore to limit the concurrency of the tasks, it can be shared by
+// multiple callers to enforce a global limit on a resource. The waiters acquire the semaphore in
+// the order they called Acquire, so a waiter that acquires a large weight will not be starved by
+// the waiters that acquire small weights.
+//
+// The semaphore can be attached to a Paralleler by Paralleler.WithSemaphore, or to the
+// package-level functions by the context that is created by ContextWithSemaphore, and every task
+// takes a weight of 1 from the semaphore while it's running.
+//
+//	// Allows no more than 10 running requests to the backend for all callers.
+//	sem := async.NewSemaphore(10)
+//
+//	ctx := async.ContextWithSemaphore(context.Background(), sem)
+//	out, err := async.ParallelWithContext(ctx, 0, fn1, fn2, fn3)
+type Semaphore struct {
+	locker sync.Mutex
+	// size is the max weight of the semaphore.
+	size int
+	// cur is the weight that has been acquired.
+	cur int
+	// waiters is the list of the waiters that are waiting for the semaphore.
+	waiters list.List
+}
+
+// semaphoreWaiter is a waiter that is waiting for the weight of the semaphore.
+type semaphoreWaiter struct {
+	// n is the weight to acquire.
+	n int
+	// ready is the channel that will be closed after the weight was acquired.
+	ready chan struct{}
+}
+
+// semaphoreContextKey is the key of the semaphore in the context.
+type semaphoreContextKey struct{}
+
+// NewSemaphore creates a semaphore with the max weight. It'll panic if the size is less than or
+// equal to 0.
+func NewSemaphore(size int) *Semaphore {
+	if size <= 0 {
+		panic(ErrInvalidConcurrency)
+	}
+
+	return &Semaphore{
+		size: size,
+	}
+}
+
+// Acquire acquires the weight of n from the semaphore, and it blocks until the weight is available
+// or the context is done. It returns ErrContextCanceled without acquiring any weight if the
+// context is done (canceled or timeout) before the weight is available. It'll panic if n is less
+// than 0 or greater than the size of the semaphore.
+func (s *Semaphore) Acquire(ctx context.Context, n int) error {
+	s.validateWeight(n)
+	ctx = getContext(ctx)
+
+	s.locker.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.locker.Unlock()
+		return nil
+	}
+
+	w := &semaphoreWaiter{
+		n:     n,
+		ready: make(chan struct{}),
+	}
+	elem := s.waiters.PushBack(w)
+	s.locker.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.locker.Lock()
+		defer s.locker.Unlock()
+
+		select {
+		case <-w.ready:
+			// the weight was acquired after the context is done, gives it back.
+			s.cur -= n
+		default:
+			s.waiters.Remove(elem)
+		}
+		// the following waiters may be able to acquire the weight after the waiter left.
+		s.notifyWaiters()
+
+		return ErrContextCanceled
+	}
+}
+
+// TryAcquire tries to acquire the weight of n from the semaphore without blocking, it returns false
+// and acquires nothing if the weight is not available. It'll panic if n is less than 0 or greater
+// than the size of the semaphore.
+func (s *Semaphore) TryAcquire(n int) bool {
+	s.validateWeight(n)
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if s.size-s.cur < n || s.waiters.Len() > 0 {
+		return false
+	}
+
+	s.cur += n
+	return true
+}
+
+// Release releases the weight of n to the semaphore. It'll panic if n is less than 0 or greater
+// than the acquired weight.
+func (s *Semaphore) Release(n int) {
+	s.validateWeight(n)
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if n > s.cur {
+		panic(ErrInvalidSemaphoreWeight)
+	}
+
+	s.cur -= n
+	s.notifyWaiters()
+}
+
+// Available returns the weight that can be acquired from the semaphore.
+func (s *Semaphore) Available() int {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.size - s.cur
+}
+
+// validateWeight checks the weight to acquire or release, and it'll panic if the weight is less
+// than 0 or greater than the size of the semaphore.
+func (s *Semaphore) validateWeight(n int) {
+	if n < 0 || n > s.size {
+		panic(ErrInvalidSemaphoreWeight)
+	}
+}
+
+// notifyWaiters gives the available weight to the waiters in order, it stops at the first waiter
+// that the weight is not enough for it. It must be called with the lock held.
+func (s *Semaphore) notifyWaiters() {
+	for {
+		elem := s.waiters.Front()
+		if elem == nil {
+			return
+		}
+
+		w := elem.Value.(*semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+
+		s.cur += w.n
+		s.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
+
+// ContextWithSemaphore returns a copy of the context that carries the semaphore. The Paralleler
+// and the package-level functions that run the tasks by it will take a weight of 1 from the
+// semaphore for every running task, and the semaphore in the context will be ignored if the
+// Paralleler has its own semaphore. The package-level functions that honour the semaphore are All,
+// AllCompleted, AllOf, AllCompletedOf, Parallel, ParallelCompleted, ParallelOf,
+// ParallelCompletedOf, Times, TimesLimit, TimesSeries, TimesRateLimit, Map, MapLimit, Each,
+// EachLimit, EachCompleted, EachLimitCompleted, EachMap, EachMapLimit, EachMapCompleted,
+// EachMapLimitCompleted, Filter, FilterLimit, Reject, RejectLimit, Partition, PartitionLimit, Some,
+// SomeLimit, Every, EveryLimit, Detect, DetectLimit, GroupBy, GroupByLimit, SortBy, SortByLimit,
+// Concat, ConcatLimit, and ParallelReduce, with their WithContext variants. Other functions, like
+// Race, Any, RaceCancel, Auto, Graph, Queue, PriorityQueue, and the Series variants of the
+// collection helpers, do not acquire the semaphore.
+//
+// The running tasks receive a context that does not carry the semaphore they are holding, so the
+// nested calls in the tasks will not acquire the semaphore again.
+func ContextWithSemaphore(ctx context.Context, sem *Semaphore) context.Context {
+	return context.WithValue(getContext(ctx), semaphoreContextKey{}, sem)
+}
+
+// getContextSemaphore returns the semaphore that the context carries, or nil if there is no
+// semaphore in the context.
+func getContextSemaphore(ctx context.Context) *Semaphore {
+	sem, _ := ctx.Value(semaphoreContextKey{}).(*Semaphore)
+	return sem
+}
+
+// withoutSemaphore returns a copy of the context that hides the semaphore if the context carries
+// it, it's used to pass to the tasks that have held a weight of the semaphore.
+func withoutSemaphore(ctx context.Context, sem *Semaphore) context.Context {
+	if sem == nil || getContextSemaphore(ctx) != sem {
+		return ctx
+	}
+
+	return context.WithValue(ctx, semaphoreContextKey{}, (*Semaphore)(nil))
+}
@@ -0,0 +1,201 @@
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestSemaphore(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(3)
+
+	a.NilNow(sem.Acquire(context.Background(), 2))
+	a.EqualNow(sem.Available(), 1)
+	a.TrueNow(sem.TryAcquire(1))
+	a.NotTrueNow(sem.TryAcquire(1))
+	a.EqualNow(sem.Available(), 0)
+
+	sem.Release(3)
+	a.EqualNow(sem.Available(), 3)
+	a.TrueNow(sem.TryAcquire(0))
+}
+
+func TestNewSemaphoreWithInvalidSize(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.NewSemaphore(0)
+	}, async.ErrInvalidConcurrency)
+	a.PanicOfNow(func() {
+		async.NewSemaphore(-1)
+	}, async.ErrInvalidConcurrency)
+}
+
+func TestSemaphoreWithInvalidWeight(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(2)
+
+	a.PanicOfNow(func() {
+		sem.Acquire(context.Background(), 3)
+	}, async.ErrInvalidSemaphoreWeight)
+	a.PanicOfNow(func() {
+		sem.TryAcquire(-1)
+	}, async.ErrInvalidSemaphoreWeight)
+	a.PanicOfNow(func() {
+		sem.Release(1)
+	}, async.ErrInvalidSemaphoreWeight)
+}
+
+func TestSemaphoreAcquireInOrder(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(3)
+	locker := sync.Mutex{}
+	order := make([]int, 0, 2)
+
+	a.NilNow(sem.Acquire(context.Background(), 3))
+
+	wg := sync.WaitGroup{}
+	for i, n := range []int{3, 1} {
+		wg.Add(1)
+		go func(i, n int) {
+			defer wg.Done()
+			a.NilNow(sem.Acquire(context.Background(), n))
+			locker.Lock()
+			order = append(order, i)
+			locker.Unlock()
+			sem.Release(n)
+		}(i, n)
+		// waits for the goroutine to be queued.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the waiter of weight 1 will not go before the waiter of weight 3.
+	sem.Release(1)
+	time.Sleep(10 * time.Millisecond)
+	a.EqualNow(sem.Available(), 1)
+	a.NotTrueNow(sem.TryAcquire(1))
+
+	sem.Release(2)
+	wg.Wait()
+	a.EqualNow(order, []int{0, 1})
+	a.EqualNow(sem.Available(), 3)
+}
+
+func TestSemaphoreAcquireWithContext(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(2)
+
+	a.NilNow(sem.Acquire(context.Background(), 1))
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	a.IsErrorNow(sem.Acquire(ctx, 2), async.ErrContextCanceled)
+	a.EqualNow(sem.Available(), 1)
+
+	// the following waiters will not be blocked by the canceled waiter.
+	a.TrueNow(sem.TryAcquire(1))
+	sem.Release(2)
+	a.EqualNow(sem.Available(), 2)
+}
+
+func TestContextWithSemaphore(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(2)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	fn := func() {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx := async.ContextWithSemaphore(context.Background(), sem)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := async.TimesWithContext(ctx, 3, fn)
+			a.NilNow(err)
+		}()
+	}
+	wg.Wait()
+
+	a.EqualNow(maxRunning.Load(), 2)
+	a.EqualNow(sem.Available(), 2)
+}
+
+func TestContextWithSemaphoreCanceled(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(1)
+	cnt := atomic.Int32{}
+
+	a.NilNow(sem.Acquire(context.Background(), 1))
+	defer sem.Release(1)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	ctx = async.ContextWithSemaphore(ctx, sem)
+
+	_, err := async.ParallelWithContext(ctx, 0, func() {
+		cnt.Add(1)
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+
+	_, err = async.AllCompletedWithContext(ctx, func() {
+		cnt.Add(1)
+	})
+	a.NotNilNow(err)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.EqualNow(cnt.Load(), 0)
+}
+
+func TestContextWithSemaphoreNestedCalls(t *testing.T) {
+	a := assert.New(t)
+	sem := async.NewSemaphore(2)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), time.Second)
+	defer canFunc()
+	ctx = async.ContextWithSemaphore(ctx, sem)
+
+	// the nested calls will not acquire the semaphore that the outer tasks are holding.
+	square := func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	}
+	out, err := async.MapWithContext(ctx, []int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+		ret, err := async.MapWithContext(ctx, []int{n, n}, square)
+		return ret[0] + ret[1], err
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{2, 8, 18})
+	a.EqualNow(sem.Available(), 2)
+}
+
+func ExampleSemaphore() {
+	sem := async.NewSemaphore(2)
+	ctx := async.ContextWithSemaphore(context.Background(), sem)
+
+	in := []int{1, 2, 3}
+	out, err := async.MapWithContext(ctx, in, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+	fmt.Println(out, err)
+	fmt.Println(sem.Available())
+	// Output:
+	// [1 4 9] <nil>
+	// 2
+}
@@ -0,0 +1,72 @@
+package async
+
+import "context"
+
+// Some runs the predicate function with each element in the slice asynchronously, and returns
+// true if at least one element passes the predicate function. It'll return true immediately
+// after a predicate function returned true, and send a cancel signal to all other predicate
+// functions by context.
+//
+// If the predicate function returns an error or panics with any element before the result was
+// decided, it will immediately return an execution error that the index is the element's index in
+// the slice.
+//
+//	ok, err := async.Some([]string{"a", "b"}, func(ctx context.Context, key string) (bool, error) {
+//	  return Exists(key)
+//	})
+func Some[T any](in []T, fn func(context.Context, T) (bool, error)) (bool, error) {
+	return some(context.Background(), in, 0, fn)
+}
+
+// SomeWithContext runs the predicate function with each element in the slice asynchronously with
+// the specified context, and returns true if at least one element passes the predicate function.
+// It'll return true immediately after a predicate function returned true, and send a cancel
+// signal to all other predicate functions by context.
+func SomeWithContext[T any](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return some(ctx, in, 0, fn)
+}
+
+// SomeLimit runs the predicate function with each element in the slice asynchronously with the
+// specified concurrency limit, and returns true if at least one element passes the predicate
+// function. It'll return true immediately after a predicate function returned true, and send a
+// cancel signal to all other predicate functions by context.
+func SomeLimit[T any](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return some(context.Background(), in, concurrency, fn)
+}
+
+// SomeLimitWithContext runs the predicate function with each element in the slice asynchronously
+// with the specified context and the concurrency limit, and returns true if at least one element
+// passes the predicate function. It'll return true immediately after a predicate function
+// returned true, and send a cancel signal to all other predicate functions by context.
+func SomeLimitWithContext[T any](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	return some(ctx, in, concurrency, fn)
+}
+
+// some runs the predicate function with each element in the slice, and returns true if at least
+// one element passes the predicate function.
+func some[T any](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (bool, error),
+) (bool, error) {
+	index, err := findIndex(parent, in, concurrency, true, fn)
+	if err != nil {
+		return false, err
+	}
+
+	return index >= 0, nil
+}
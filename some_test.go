@@ -0,0 +1,113 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestSome(t *testing.T) {
+	a := assert.New(t)
+	canceled := atomic.Int32{}
+
+	start := time.Now()
+	ok, err := async.Some([]int{1, 2, 3, 4}, func(ctx context.Context, n int) (bool, error) {
+		if n == 2 {
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return false, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return false, nil
+		}
+	})
+	a.NilNow(err)
+	a.TrueNow(ok)
+	a.LtNow(time.Since(start), 50*time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+	a.EqualNow(canceled.Load(), 3)
+
+	ok, err = async.Some([]int{1, 3, 5}, isEven)
+	a.NilNow(err)
+	a.NotTrueNow(ok)
+
+	ok, err = async.Some([]int{}, isEven)
+	a.NilNow(err)
+	a.NotTrueNow(ok)
+}
+
+func TestSomeWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Some[int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestSomeWithFailure(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	ok, err := async.Some([]int{1, 2, 3}, func(ctx context.Context, n int) (bool, error) {
+		if n == 2 {
+			return false, expectedErr
+		}
+		return false, nil
+	})
+	a.IsErrorNow(err, expectedErr)
+	a.EqualNow(err.Error(), "function 1 error: expected error")
+	a.NotTrueNow(ok)
+}
+
+func TestSomeLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+
+	in := []int{1, 2, 3, 4, 5}
+	ok, err := async.SomeLimit(in, 1, func(ctx context.Context, n int) (bool, error) {
+		a.EqualNow(running.Add(1), 1)
+		defer running.Add(-1)
+		return n == 2, nil
+	})
+	a.NilNow(err)
+	a.TrueNow(ok)
+
+	ok, err = async.SomeLimitWithContext(context.Background(), in, 2, isEven)
+	a.NilNow(err)
+	a.TrueNow(ok)
+}
+
+func TestSomeWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer canFunc()
+
+	in := []int{1, 2}
+	_, err := async.SomeWithContext(ctx, in, func(ctx context.Context, n int) (bool, error) {
+		time.Sleep(time.Duration(n*100) * time.Millisecond)
+		return true, nil
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+}
+
+func ExampleSome() {
+	ok, err := async.Some([]int{1, 2, 3}, func(ctx context.Context, n int) (bool, error) {
+		return n > 2, nil
+	})
+	fmt.Println(ok)
+	fmt.Println(err)
+	// Output:
+	// true
+	// <nil>
+}
@@ -0,0 +1,98 @@
+package async
+
+import (
+	"context"
+	"sort"
+)
+
+// ordered is a constraint that permits any ordered type that supports the operators < <= >= >.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// SortBy runs the function with each element in the slice asynchronously to get the sort key of
+// the element, and returns a new slice that the elements are sorted by the keys in ascending
+// order. The sort is stable, the elements with the equal keys keep the same order as they are in
+// the slice. If the function returns an error or panics with any element, it will immediately
+// return an execution error that the index is the element's index in the slice, and send a cancel
+// signal to all other functions by context.
+//
+//	users := []int{1, 2, 3}
+//	out, err := async.SortBy(users, func(ctx context.Context, id int) (int, error) {
+//	  return GetUserScore(id)
+//	})
+func SortBy[T any, K ordered](in []T, fn func(context.Context, T) (K, error)) ([]T, error) {
+	return sortBy(context.Background(), in, 0, fn)
+}
+
+// SortByWithContext runs the function with each element in the slice asynchronously with the
+// specified context to get the sort key of the element, and returns a new slice that the elements
+// are sorted by the keys in ascending order. If the function returns an error or panics with any
+// element, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func SortByWithContext[T any, K ordered](
+	ctx context.Context,
+	in []T,
+	fn func(context.Context, T) (K, error),
+) ([]T, error) {
+	return sortBy(ctx, in, 0, fn)
+}
+
+// SortByLimit runs the function with each element in the slice asynchronously with the specified
+// concurrency limit to get the sort key of the element, and returns a new slice that the elements
+// are sorted by the keys in ascending order. If the function returns an error or panics with any
+// element, it will immediately return an execution error, and send a cancel signal to all other
+// functions by context.
+func SortByLimit[T any, K ordered](
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) ([]T, error) {
+	return sortBy(context.Background(), in, concurrency, fn)
+}
+
+// SortByLimitWithContext runs the function with each element in the slice asynchronously with the
+// specified context and the concurrency limit to get the sort key of the element, and returns a
+// new slice that the elements are sorted by the keys in ascending order. If the function returns
+// an error or panics with any element, it will immediately return an execution error, and send a
+// cancel signal to all other functions by context.
+func SortByLimitWithContext[T any, K ordered](
+	ctx context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) ([]T, error) {
+	return sortBy(ctx, in, concurrency, fn)
+}
+
+// sortBy gets the sort keys of the elements with the specified concurrency, and sorts the elements
+// by the keys.
+func sortBy[T any, K ordered](
+	parent context.Context,
+	in []T,
+	concurrency int,
+	fn func(context.Context, T) (K, error),
+) ([]T, error) {
+	keys, err := mapSlice(parent, in, concurrency, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]int, len(in))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return keys[indexes[i]] < keys[indexes[j]]
+	})
+
+	out := make([]T, len(in))
+	for i, index := range indexes {
+		out[i] = in[index]
+	}
+
+	return out, nil
+}
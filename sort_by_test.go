@@ -0,0 +1,108 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+func TestSortBy(t *testing.T) {
+	a := assert.New(t)
+
+	in := []string{"ccc", "a", "dd", "b", "eee"}
+	out, err := async.SortBy(in, func(ctx context.Context, s string) (int, error) {
+		time.Sleep(time.Duration(len(s)*10) * time.Millisecond)
+		return len(s), nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []string{"a", "b", "dd", "ccc", "eee"})
+	a.EqualNow(in, []string{"ccc", "a", "dd", "b", "eee"})
+
+	out, err = async.SortBy([]string{}, func(ctx context.Context, s string) (string, error) {
+		return s, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []string{})
+}
+
+func TestSortByWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.SortBy[int, int]([]int{1}, nil)
+	}, async.ErrNotFunction)
+}
+
+func TestSortByWithFailure(t *testing.T) {
+	a := assert.New(t)
+
+	out, err := async.SortBy([]int{1, 2, 3}, func(ctx context.Context, n int) (float64, error) {
+		if n == 3 {
+			panic("expected panic")
+		}
+		return float64(n), nil
+	})
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "function 2 error: expected panic")
+	a.NilNow(out)
+}
+
+func TestSortByLimit(t *testing.T) {
+	a := assert.New(t)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+
+	in := []int{3, 1, 2, 5, 4}
+	out, err := async.SortByLimit(in, 2, func(ctx context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		return -n, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{5, 4, 3, 2, 1})
+	a.EqualNow(maxRunning.Load(), 2)
+
+	ctx := context.Background()
+	out, err = async.SortByLimitWithContext(ctx, in, 1, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	a.NilNow(err)
+	a.EqualNow(out, []int{1, 2, 3, 4, 5})
+}
+
+func TestSortByWithContext(t *testing.T) {
+	a := assert.New(t)
+	expectedErr := errors.New("expected error")
+
+	ctx := context.Background()
+	_, err := async.SortByWithContext(ctx, []int{1, 2}, func(ctx context.Context, n int) (int, error) {
+		return 0, expectedErr
+	})
+	a.IsErrorNow(err, expectedErr)
+}
+
+func ExampleSortBy() {
+	in := []string{"ccc", "a", "bb"}
+	out, err := async.SortBy(in, func(ctx context.Context, s string) (int, error) {
+		return len(s), nil
+	})
+	fmt.Println(out)
+	fmt.Println(err)
+	// Output:
+	// [a bb ccc]
+	// <nil>
+}
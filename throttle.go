@@ -0,0 +1,68 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+type ThrottleOptions struct {
+	// Leading indicates to invoke the function on the leading edge of the interval.
+	Leading bool
+	// Trailing indicates to invoke the function on the trailing edge of the interval if it was
+	// called again during the interval. Both of the leading edge and the trailing edge are enabled
+	// if both of Leading and Trailing are false (default).
+	Trailing bool
+}
+
+// Throttle creates a throttled function that invokes the function at most once per every
+// interval. The first call invokes the function immediately, and the calls during the interval are
+// coalesced into a single invocation on the trailing edge of the interval. Every caller receives
+// the return values and the error of the invocation that it was coalesced into, and the panic of
+// the function will be caught and returned as an error.
+//
+// The context of the throttled function is used for waiting for the result only, the invocation
+// will not be canceled if a caller's context is done.
+//
+//	refresh := async.Throttle(func(ctx context.Context) (int, error) {
+//	  return RefreshCache(ctx)
+//	}, time.Second)
+//	out, err := refresh(context.Background())
+func Throttle(
+	fn AsyncFn,
+	interval time.Duration,
+	opts ...ThrottleOptions,
+) func(context.Context) ([]any, error) {
+	return throttle(context.Background(), fn, interval, opts...)
+}
+
+// ThrottleWithContext creates a throttled function that invokes the function at most once per
+// every interval, and the context will pass to the function.
+func ThrottleWithContext(
+	ctx context.Context,
+	fn AsyncFn,
+	interval time.Duration,
+	opts ...ThrottleOptions,
+) func(context.Context) ([]any, error) {
+	return throttle(ctx, fn, interval, opts...)
+}
+
+// throttle creates a throttled function with the options.
+func throttle(
+	ctx context.Context,
+	fn AsyncFn,
+	interval time.Duration,
+	opts ...ThrottleOptions,
+) func(context.Context) ([]any, error) {
+	opt := ThrottleOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if !opt.Leading && !opt.Trailing {
+		opt.Leading = true
+		opt.Trailing = true
+	}
+
+	c := newCoalescer(ctx, fn, interval, opt.Leading, opt.Trailing, false)
+
+	return c.call
+}
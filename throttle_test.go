@@ -0,0 +1,180 @@
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghosind/go-assert"
+	"github.com/ghosind/go-async"
+)
+
+// callCoalescedFn calls the function in a new goroutine after the delay, and sends the first
+// return value or the error to the channel.
+func callCoalescedFn(
+	wg *sync.WaitGroup,
+	fn func(context.Context) ([]any, error),
+	delay time.Duration,
+) <-chan any {
+	ch := make(chan any, 1)
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		time.Sleep(delay)
+		out, err := fn(context.Background())
+		if err != nil {
+			ch <- err
+		} else {
+			ch <- out[0]
+		}
+	}()
+
+	return ch
+}
+
+func TestThrottle(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Throttle(func() int32 {
+		return cnt.Add(1)
+	}, 30*time.Millisecond)
+
+	start := time.Now()
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	ch3 := callCoalescedFn(&wg, fn, 15*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.LtNow(time.Since(start), 10*time.Millisecond)
+	a.EqualNow(<-ch2, int32(2))
+	a.EqualNow(<-ch3, int32(2))
+	a.GteNow(time.Since(start), 30*time.Millisecond)
+	wg.Wait()
+
+	// the trailing invocation starts a new interval.
+	ch4 := callCoalescedFn(&wg, fn, 0)
+	a.EqualNow(<-ch4, int32(3))
+	a.GteNow(time.Since(start), 60*time.Millisecond)
+	a.EqualNow(cnt.Load(), 3)
+}
+
+func TestThrottleWithNilFunction(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.Throttle(nil, time.Second)
+	}, async.ErrNotFunction)
+}
+
+func TestThrottleLeadingOnly(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Throttle(func() int32 {
+		return cnt.Add(1)
+	}, 30*time.Millisecond, async.ThrottleOptions{
+		Leading: true,
+	})
+
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	ch3 := callCoalescedFn(&wg, fn, 40*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.EqualNow(<-ch2, int32(1))
+	a.EqualNow(<-ch3, int32(2))
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 2)
+}
+
+func TestThrottleTrailingOnly(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	fn := async.Throttle(func() int32 {
+		return cnt.Add(1)
+	}, 30*time.Millisecond, async.ThrottleOptions{
+		Trailing: true,
+	})
+
+	start := time.Now()
+	ch1 := callCoalescedFn(&wg, fn, 0)
+	ch2 := callCoalescedFn(&wg, fn, 10*time.Millisecond)
+	a.EqualNow(<-ch1, int32(1))
+	a.EqualNow(<-ch2, int32(1))
+	a.GteNow(time.Since(start), 30*time.Millisecond)
+	wg.Wait()
+	a.EqualNow(cnt.Load(), 1)
+}
+
+func TestThrottleWithPanic(t *testing.T) {
+	a := assert.New(t)
+
+	fn := async.Throttle(func() error {
+		panic("expected panic")
+	}, 10*time.Millisecond)
+
+	out, err := fn(context.Background())
+	a.NotNilNow(err)
+	a.EqualNow(err.Error(), "expected panic")
+	a.EqualNow(out, []any{nil})
+}
+
+func TestThrottleWithCallerContext(t *testing.T) {
+	a := assert.New(t)
+	cnt := atomic.Int32{}
+
+	fn := async.Throttle(func() int32 {
+		return cnt.Add(1)
+	}, 30*time.Millisecond)
+
+	_, err := fn(context.Background())
+	a.NilNow(err)
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer canFunc()
+	_, err = fn(ctx)
+	a.IsErrorNow(err, async.ErrContextCanceled)
+
+	// the trailing invocation will not be canceled by the caller.
+	time.Sleep(30 * time.Millisecond)
+	a.EqualNow(cnt.Load(), 2)
+}
+
+func TestThrottleWithContext(t *testing.T) {
+	a := assert.New(t)
+
+	//lint:ignore SA1029 for test case only
+	ctx := context.WithValue(context.Background(), "key", "value")
+	fn := async.ThrottleWithContext(ctx, func(ctx context.Context) (string, error) {
+		return ctx.Value("key").(string), nil
+	}, 10*time.Millisecond)
+
+	out, err := fn(context.Background())
+	a.NilNow(err)
+	a.EqualNow(out, []any{"value", nil})
+}
+
+func ExampleThrottle() {
+	cnt := atomic.Int32{}
+	fn := async.Throttle(func() int32 {
+		return cnt.Add(1)
+	}, 100*time.Millisecond, async.ThrottleOptions{
+		Leading: true,
+	})
+
+	for i := 0; i < 3; i++ {
+		out, err := fn(context.Background())
+		fmt.Println(out, err)
+	}
+	// Output:
+	// [1] <nil>
+	// [1] <nil>
+	// [1] <nil>
+}
@@ -10,13 +10,13 @@ import "context"
 //		return CallAPI()
 //	})
 func Times(n int, fn AsyncFn) ([][]any, error) {
-	return times(context.Background(), n, 0, fn)
+	return times(context.Background(), n, 0, nil, fn)
 }
 
 // TimesWithContext executes the function n times with the context, and returns the results. It'll
 // terminate if any function panics or returns an error.
 func TimesWithContext(ctx context.Context, n int, fn AsyncFn) ([][]any, error) {
-	return times(ctx, n, 0, fn)
+	return times(ctx, n, 0, nil, fn)
 }
 
 // TimesLimit executes the function n times with the specified concurrency limit, and returns the
@@ -27,14 +27,14 @@ func TimesWithContext(ctx context.Context, n int, fn AsyncFn) ([][]any, error) {
 //		return CallAPI()
 //	})
 func TimesLimit(n, concurrency int, fn AsyncFn) ([][]any, error) {
-	return times(context.Background(), n, concurrency, fn)
+	return times(context.Background(), n, concurrency, nil, fn)
 }
 
 // TimesLimitWithContext executes the function n times with the specified concurrency limit and
 // the context, and returns the results. It'll terminate if any function panics or returns an
 // error.
 func TimesLimitWithContext(ctx context.Context, n, concurrency int, fn AsyncFn) ([][]any, error) {
-	return times(ctx, n, concurrency, fn)
+	return times(ctx, n, concurrency, nil, fn)
 }
 
 // TimesSeries executes the function n times with only a single invocation at a time, and returns
@@ -45,26 +45,60 @@ func TimesLimitWithContext(ctx context.Context, n, concurrency int, fn AsyncFn)
 //		return CallAPI()
 //	})
 func TimesSeries(n int, fn AsyncFn) ([][]any, error) {
-	return times(context.Background(), n, 1, fn)
+	return times(context.Background(), n, 1, nil, fn)
 }
 
 // TimesSeriesWithContext executes the function n times with the context and only a single
 // invocation at a time, and returns the results. It'll terminate if any function panics or
 // returns an error.
 func TimesSeriesWithContext(ctx context.Context, n int, fn AsyncFn) ([][]any, error) {
-	return times(ctx, n, 1, fn)
+	return times(ctx, n, 1, nil, fn)
 }
 
-// times executes the function n times withe the specified concurrency.
-func times(parent context.Context, n, concurrency int, fn AsyncFn) ([][]any, error) {
+// TimesRateLimit executes the function n times with the specified rate limit, the functions will
+// be started no more than the rate per second with the burst. It returns the results, and it'll
+// terminate if any function panics or returns an error. It'll panic if the rate is less than or
+// equal to 0, or the burst is less than 1.
+//
+//	// Calls api 10 times but no more than 2 calls per second.
+//	async.TimesRateLimit(10, 2, 1, func () error {
+//		return CallAPI()
+//	})
+func TimesRateLimit(n int, rate float64, burst int, fn AsyncFn) ([][]any, error) {
+	return times(context.Background(), n, 0, NewRateLimiter(rate, burst), fn)
+}
+
+// TimesRateLimitWithContext executes the function n times with the specified rate limit and the
+// context, and returns the results. It'll terminate if any function panics or returns an error,
+// or the context is done while waiting for the rate limiter.
+func TimesRateLimitWithContext(
+	ctx context.Context,
+	n int,
+	rate float64,
+	burst int,
+	fn AsyncFn,
+) ([][]any, error) {
+	return times(ctx, n, 0, NewRateLimiter(rate, burst), fn)
+}
+
+// times executes the function n times withe the specified concurrency and the rate limiter.
+func times(
+	parent context.Context,
+	n, concurrency int,
+	limiter *RateLimiter,
+	fn AsyncFn,
+) ([][]any, error) {
 	paralleler := builtinPool.Get().(*Paralleler)
 	defer func() {
+		// resets the rate limiter to avoid affecting other built-in functions.
+		paralleler.limiter = nil
 		builtinPool.Put(paralleler)
 	}()
 
 	paralleler.
 		WithConcurrency(concurrency).
 		WithContext(parent)
+	paralleler.limiter = limiter
 
 	tasks := make([]AsyncFn, 0, n)
 	for i := 0; i < n; i++ {
@@ -189,3 +189,67 @@ func ExampleTimesSeries() {
 	// [[1] [2] [3] [4] [5]]
 	// <nil>
 }
+
+func TestTimesRateLimit(t *testing.T) {
+	a := assert.New(t)
+	i := atomic.Int32{}
+
+	start := time.Now()
+	out, err := async.TimesRateLimit(4, 50, 2, func() {
+		i.Add(1)
+	})
+	dur := time.Since(start)
+	a.NilNow(err)
+	a.EqualNow(out, make([][]any, 4))
+	a.EqualNow(i.Load(), 4)
+	// the first 2 calls use the burst, and the others wait for 20ms each.
+	a.GteNow(dur, 35*time.Millisecond)
+	a.LtNow(dur, 60*time.Millisecond)
+}
+
+func TestTimesRateLimitWithInvalidLimit(t *testing.T) {
+	a := assert.New(t)
+
+	a.PanicOfNow(func() {
+		async.TimesRateLimit(1, 0, 1, func() {})
+	}, async.ErrInvalidRateLimit)
+	a.PanicOfNow(func() {
+		async.TimesRateLimit(1, 1, 0, func() {})
+	}, async.ErrInvalidRateLimit)
+}
+
+func TestTimesRateLimitWithContext(t *testing.T) {
+	a := assert.New(t)
+	i := atomic.Int32{}
+
+	ctx, canFunc := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer canFunc()
+
+	start := time.Now()
+	_, err := async.TimesRateLimitWithContext(ctx, 5, 10, 1, func() {
+		i.Add(1)
+	})
+	a.IsErrorNow(err, async.ErrContextCanceled)
+	a.LtNow(time.Since(start), 50*time.Millisecond)
+	a.EqualNow(i.Load(), 1)
+
+	// the rate limiter does not affect other functions.
+	start = time.Now()
+	_, err = async.Times(5, func() {})
+	a.NilNow(err)
+	a.LtNow(time.Since(start), 10*time.Millisecond)
+}
+
+func ExampleTimesRateLimit() {
+	i := atomic.Int32{}
+	out, err := async.TimesRateLimit(3, 100, 1, func() int32 {
+		return i.Add(1)
+	})
+	fmt.Println(i.Load())
+	fmt.Println(len(out))
+	fmt.Println(err)
+	// Output:
+	// 3
+	// 3
+	// <nil>
+}